@@ -0,0 +1,217 @@
+package burndown
+
+import (
+	"math"
+	"math/rand/v2"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"go-burndown/config"
+)
+
+const (
+	// BacktestMovingAverage forecasts at the moving average velocity, as the Projections sheet does.
+	BacktestMovingAverage = "moving-average"
+	// BacktestMonteCarlo forecasts the median of simulations that resample velocities from the window.
+	BacktestMonteCarlo = "monte-carlo"
+)
+
+const (
+	// How many simulations each Monte Carlo forecast runs when not configured.
+	//revive:disable:var-naming
+	_DEFAULT_MONTE_CARLO_TRIALS = 1000
+	// A simulation that hasn't finished after this many periods is treated as never finishing.
+	_MAX_SIMULATED_PERIODS = 1000
+)
+
+// ErrNotEnoughHistory is returned when there is nothing to backtest against yet: the work isn't done and there is
+// no current forecast.
+var ErrNotEnoughHistory = errors.New("not enough history to backtest: no current forecast to compare against")
+
+// The windows backtested when none are configured (along with the configured moving average window).
+var defaultBacktestWindows = []uint{4, 8, 12}
+
+// BacktestMethod is one way of forecasting that a backtest evaluates.
+type BacktestMethod struct {
+	Name   string
+	Window uint
+}
+
+// BacktestRow is what each method forecast at the end of one past period, using only the velocities known then.
+type BacktestRow struct {
+	Point Point
+	// Forecasts and Errors are parallel to the backtest's methods. A zero date means the method had no forecast.
+	Forecasts []time.Time
+	// Errors are how many days late (positive) or early (negative) each forecast was compared to the reference.
+	Errors []float64
+}
+
+// BacktestAccuracy summarizes how close a method's forecasts came to the reference date.
+type BacktestAccuracy struct {
+	Method       BacktestMethod
+	Forecasts    int
+	MeanAbsError float64 // Days.
+	MeanError    float64 // Days; positive means forecasts were late on average.
+}
+
+// Backtest replays the forecast at every past period and compares it to the reference completion date.
+type Backtest struct {
+	Methods []BacktestMethod
+	// Reference is when the work was actually completed, or the current mean forecast if it isn't complete yet.
+	// The latest period's forecasts aren't scored against the current forecast, which one of them is.
+	Reference         time.Time
+	ReferenceIsActual bool
+	Rows              []BacktestRow
+	// Accuracy has one entry per method, most accurate first.
+	Accuracy []BacktestAccuracy
+}
+
+// NewBacktest replays the series' history with each configured method and window.
+func NewBacktest(config *config.Config, series Series) (Backtest, error) {
+	if len(series.Points) == 0 {
+		return Backtest{}, errors.WithStack(ErrNotEnoughHistory)
+	}
+
+	backtest := Backtest{Methods: backtestMethods(config)}
+
+	// Find the reference date and the periods that were forecasting it. Periods before any issue was created have
+	// nothing remaining too, so the work is only complete once it had scope and had work remaining the period before.
+	lastIndex := len(series.Points) - 1
+	for periodIndex, point := range series.Points {
+		if periodIndex > 0 && point.Remaining <= 0 && point.Scope > 0 && series.Points[periodIndex-1].Remaining > 0 {
+			backtest.Reference = point.Period.End
+			backtest.ReferenceIsActual = true
+			lastIndex = periodIndex - 1
+			break
+		}
+	}
+	if !backtest.ReferenceIsActual {
+		forecast, ok := series.Forecast(lastIndex)
+		if !ok || forecast.Mean.IsZero() {
+			return Backtest{}, errors.WithStack(ErrNotEnoughHistory)
+		}
+		backtest.Reference = forecast.Mean
+	}
+
+	trials := config.Backtest.MonteCarloTrials
+	if trials == 0 {
+		trials = _DEFAULT_MONTE_CARLO_TRIALS
+	}
+	// A fixed seed keeps reports reproducible.
+	random := rand.New(rand.NewPCG(1, 1))
+
+	for periodIndex := 0; periodIndex <= lastIndex; periodIndex++ {
+		point := series.Points[periodIndex]
+		row := BacktestRow{
+			Point:     point,
+			Forecasts: make([]time.Time, len(backtest.Methods)),
+			Errors:    make([]float64, len(backtest.Methods)),
+		}
+
+		// Like the Projections sheet, forecasts need three velocities.
+		velocities := series.Velocities(periodIndex)
+		if len(velocities) >= 3 {
+			for methodIndex, method := range backtest.Methods {
+				var forecast time.Time
+				switch method.Name {
+				case BacktestMovingAverage:
+					avg, _ := VelocityStats(velocities, method.Window)
+					forecast, _ = ProjectDate(point.Period.End, point.Remaining, avg, series.Timeline.WorkdaysPerPeriod)
+				case BacktestMonteCarlo:
+					forecast, _ = monteCarloDate(random, point, velocities, method.Window, trials, series.Timeline.WorkdaysPerPeriod)
+				}
+				if !forecast.IsZero() {
+					row.Forecasts[methodIndex] = forecast
+					row.Errors[methodIndex] = forecast.Sub(backtest.Reference).Hours() / 24
+				}
+			}
+		}
+
+		backtest.Rows = append(backtest.Rows, row)
+	}
+
+	scoredRows := backtest.Rows
+	if !backtest.ReferenceIsActual {
+		scoredRows = scoredRows[:len(scoredRows)-1]
+	}
+	backtest.Accuracy = backtestAccuracy(backtest.Methods, scoredRows)
+
+	return backtest, nil
+}
+
+// backtestMethods pairs each method with each window.
+func backtestMethods(config *config.Config) (methods []BacktestMethod) {
+	windows := slices.Clone(config.Backtest.Windows)
+	if len(windows) == 0 {
+		windows = slices.Clone(defaultBacktestWindows)
+		if !slices.Contains(windows, config.MovingAvgWeeks) {
+			windows = append(windows, config.MovingAvgWeeks)
+		}
+	}
+	slices.Sort(windows)
+
+	for _, name := range []string{BacktestMovingAverage, BacktestMonteCarlo} {
+		for _, window := range windows {
+			methods = append(methods, BacktestMethod{Name: name, Window: window})
+		}
+	}
+	return methods
+}
+
+// monteCarloDate simulates burning down the remaining work by resampling the recent velocities,
+// and projects the median number of periods the simulations took.
+func monteCarloDate(random *rand.Rand, point Point, velocities []float64, window, trials uint, workdaysPerPeriod float64) (projected time.Time, ok bool) {
+	n := min(len(velocities), int(window))
+	recent := velocities[len(velocities)-n:]
+
+	periodsNeeded := make([]int, 0, trials)
+	for range trials {
+		remaining := point.Remaining
+		periods := 0
+		for remaining > 0 && periods < _MAX_SIMULATED_PERIODS {
+			remaining -= recent[random.IntN(len(recent))]
+			periods++
+		}
+		periodsNeeded = append(periodsNeeded, periods)
+	}
+	sort.Ints(periodsNeeded)
+
+	median := periodsNeeded[len(periodsNeeded)/2]
+	if median >= _MAX_SIMULATED_PERIODS {
+		return time.Time{}, false
+	}
+	return addWorkdays(point.Period.End, int(math.Ceil(float64(median)*workdaysPerPeriod))), true
+}
+
+// backtestAccuracy scores each method by its mean absolute error, most accurate first.
+func backtestAccuracy(methods []BacktestMethod, rows []BacktestRow) []BacktestAccuracy {
+	accuracy := make([]BacktestAccuracy, len(methods))
+	for methodIndex, method := range methods {
+		accuracy[methodIndex].Method = method
+		for _, row := range rows {
+			if row.Forecasts[methodIndex].IsZero() {
+				continue
+			}
+			accuracy[methodIndex].Forecasts++
+			accuracy[methodIndex].MeanAbsError += math.Abs(row.Errors[methodIndex])
+			accuracy[methodIndex].MeanError += row.Errors[methodIndex]
+		}
+		if accuracy[methodIndex].Forecasts > 0 {
+			accuracy[methodIndex].MeanAbsError /= float64(accuracy[methodIndex].Forecasts)
+			accuracy[methodIndex].MeanError /= float64(accuracy[methodIndex].Forecasts)
+		}
+	}
+
+	// Methods that never forecast can't be the most accurate.
+	sort.SliceStable(accuracy, func(i, j int) bool {
+		if (accuracy[i].Forecasts == 0) != (accuracy[j].Forecasts == 0) {
+			return accuracy[j].Forecasts == 0
+		}
+		return accuracy[i].MeanAbsError < accuracy[j].MeanAbsError
+	})
+
+	return accuracy
+}
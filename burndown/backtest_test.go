@@ -0,0 +1,193 @@
+package burndown
+
+import (
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"go-burndown/config"
+)
+
+// backtestSeries builds a weekly series ending on Fridays from the scope and remaining work at the end of each
+// period, with velocity stats over a window of 4 as NewSeries computes them.
+func backtestSeries(scope, remaining []float64) Series {
+	series := Series{Timeline: Timeline{Unit: "w", WorkdaysPerPeriod: 5}}
+	var velocities []float64
+	for periodIndex, value := range remaining {
+		end := time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC).AddDate(0, 0, 7*periodIndex)
+		period := Period{Start: end.AddDate(0, 0, -6), End: end}
+		series.Timeline.Periods = append(series.Timeline.Periods, period)
+
+		point := Point{Period: period, Remaining: value, Scope: scope[periodIndex], Completed: scope[periodIndex] - value}
+		if periodIndex > 0 {
+			point.Velocity = point.Completed - series.Points[periodIndex-1].Completed
+			point.HasVelocity = true
+			velocities = append(velocities, point.Velocity)
+		}
+		if periodIndex > 1 {
+			point.AvgVelocity, point.StdDev = VelocityStats(velocities, 4)
+			point.HasAvg = true
+			point.HasStdDev = periodIndex > 2
+		}
+		series.Points = append(series.Points, point)
+	}
+	return series
+}
+
+func TestNewBacktest(t *testing.T) {
+	config := &config.Config{MovingAvgWeeks: 4, Backtest: config.BacktestConfig{Windows: []uint{4}, MonteCarloTrials: 100}}
+	date := func(month time.Month, day int) time.Time {
+		return time.Date(2025, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name              string
+		scope             []float64
+		remaining         []float64
+		err               error
+		reference         time.Time
+		referenceIsActual bool
+		rows              int
+		// Forecasts of the latest row, by method, if checked, and how many forecasts each method had scored.
+		forecasts       []time.Time
+		scoredForecasts []int
+	}{
+		{
+			name: "no periods",
+			err:  ErrNotEnoughHistory,
+		},
+		{
+			name:      "no forecast yet",
+			scope:     []float64{20, 20, 20},
+			remaining: []float64{20, 18, 16},
+			err:       ErrNotEnoughHistory,
+		},
+		{
+			name:              "scored against the actual completion",
+			scope:             []float64{40, 40, 40, 40, 40},
+			remaining:         []float64{40, 30, 20, 10, 0},
+			reference:         date(1, 31),
+			referenceIsActual: true,
+			rows:              4,
+			forecasts:         []time.Time{date(1, 31), date(1, 31)},
+			scoredForecasts:   []int{1, 1},
+		},
+		{
+			name:            "the latest forecasts aren't scored against the current forecast",
+			scope:           []float64{50, 50, 50, 50},
+			remaining:       []float64{50, 40, 30, 20},
+			reference:       date(2, 7),
+			rows:            4,
+			forecasts:       []time.Time{date(2, 7), date(2, 7)},
+			scoredForecasts: []int{0, 0},
+		},
+		{
+			name:              "nothing remaining before the issues were created isn't completion",
+			scope:             []float64{0, 0, 40, 40, 40, 40, 40},
+			remaining:         []float64{0, 0, 40, 30, 20, 10, 0},
+			reference:         date(2, 14),
+			referenceIsActual: true,
+			rows:              6,
+			scoredForecasts:   []int{3, 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backtest, err := NewBacktest(config, backtestSeries(tt.scope, tt.remaining))
+			if tt.err != nil {
+				assert.True(t, errors.Is(err, tt.err), "expected %v, got %v", tt.err, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.reference, backtest.Reference)
+			assert.Equal(t, tt.referenceIsActual, backtest.ReferenceIsActual)
+			assert.Len(t, backtest.Rows, tt.rows)
+			if tt.forecasts != nil {
+				assert.Equal(t, tt.forecasts, backtest.Rows[len(backtest.Rows)-1].Forecasts)
+			}
+			var scoredForecasts []int
+			for _, accuracy := range backtest.Accuracy {
+				scoredForecasts = append(scoredForecasts, accuracy.Forecasts)
+			}
+			assert.Equal(t, tt.scoredForecasts, scoredForecasts)
+		})
+	}
+}
+
+func TestMonteCarloDate(t *testing.T) {
+	friday := time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		remaining  float64
+		velocities []float64
+		window     uint
+		projected  time.Time
+		ok         bool
+	}{
+		{
+			name:       "steady velocity",
+			remaining:  20,
+			velocities: []float64{10, 10, 10},
+			window:     4,
+			projected:  time.Date(2025, 1, 17, 0, 0, 0, 0, time.UTC),
+			ok:         true,
+		},
+		{
+			name:       "only the most recent velocities in the window",
+			remaining:  20,
+			velocities: []float64{1, 20, 20},
+			window:     2,
+			projected:  time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC),
+			ok:         true,
+		},
+		{
+			name:       "nothing remaining",
+			velocities: []float64{10, 10, 10},
+			window:     4,
+			projected:  friday,
+			ok:         true,
+		},
+		{
+			name:       "never finishes without progress",
+			remaining:  20,
+			velocities: []float64{0, 0, 0},
+			window:     4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			random := rand.New(rand.NewPCG(1, 1))
+			point := Point{Period: Period{End: friday}, Remaining: tt.remaining}
+			projected, ok := monteCarloDate(random, point, tt.velocities, tt.window, 100, 5)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.projected, projected)
+		})
+	}
+}
+
+func TestBacktestAccuracy(t *testing.T) {
+	forecast := time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC)
+	methods := []BacktestMethod{
+		{Name: BacktestMovingAverage, Window: 4},
+		{Name: BacktestMonteCarlo, Window: 4},
+		{Name: BacktestMovingAverage, Window: 8},
+	}
+	rows := []BacktestRow{
+		{Forecasts: []time.Time{forecast, forecast, {}}, Errors: []float64{6, -1, 0}},
+		{Forecasts: []time.Time{forecast, forecast, {}}, Errors: []float64{-2, 3, 0}},
+	}
+
+	accuracy := backtestAccuracy(methods, rows)
+
+	assert.Equal(t, []BacktestAccuracy{
+		{Method: methods[1], Forecasts: 2, MeanAbsError: 2, MeanError: 1},
+		{Method: methods[0], Forecasts: 2, MeanAbsError: 4, MeanError: 2},
+		{Method: methods[2]},
+	}, accuracy)
+}
@@ -0,0 +1,134 @@
+package burndown
+
+import (
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"go-burndown/config"
+	"go-burndown/jira"
+)
+
+// Breakdown is the progress of each group of issues along one dimension, such as assignee.
+type Breakdown struct {
+	Dimension string
+	// Groups are ordered by name, with the issues in no group last.
+	Groups []Group
+}
+
+// Group is the progress of the issues in one group over every period.
+type Group struct {
+	Name   string
+	Points []GroupPoint
+}
+
+// GroupPoint is a group's progress at the end of a period.
+type GroupPoint struct {
+	Period Period
+	// Completed is the work credited to the group so far. Each period's earned value is credited to the group
+	// the issue was in at the end of that period, so work done before a reassignment stays with the earlier group.
+	Completed float64
+	// Remaining is the work left on the issues in the group at the end of the period.
+	Remaining float64
+	// Velocity is the work credited to the group during the period. The first period has none.
+	Velocity    float64
+	HasVelocity bool
+}
+
+// NoGroup names the group of issues with no value for the dimension, such as unassigned issues.
+func NoGroup(dimension string) string {
+	return "(No " + dimension + ")"
+}
+
+// NewBreakdown splits the series' progress by a dimension. Assignees and teams are taken at the end of each period
+// from the changelog; components are taken as they are now, and an issue with several components counts toward each.
+func NewBreakdown(config *config.Config, series Series, dimension string) (Breakdown, error) {
+	groupsOf, err := groupsByDimension(dimension, func(issue *jira.Issue, date time.Time) string {
+		return issue.TeamOnDate(config, date)
+	})
+	if err != nil {
+		return Breakdown{}, errors.WithStack(err)
+	}
+
+	breakdown := Breakdown{Dimension: dimension}
+	groups := map[string]*Group{}
+	groupNamed := func(name string) *Group {
+		if name == "" {
+			name = NoGroup(dimension)
+		}
+		group, ok := groups[name]
+		if !ok {
+			group = &Group{Name: name, Points: make([]GroupPoint, len(series.Timeline.Periods))}
+			for periodIndex, period := range series.Timeline.Periods {
+				group.Points[periodIndex].Period = period
+			}
+			groups[name] = group
+		}
+		return group
+	}
+
+	for periodIndex, period := range series.Timeline.Periods {
+		for i := range series.Issues {
+			progress := &series.Issues[i]
+			names := groupsOf(progress.Issue, period.End)
+			if len(names) == 0 {
+				names = []string{""}
+			}
+
+			earned := progress.EarnedValue(periodIndex)
+			if periodIndex > 0 {
+				earned -= progress.EarnedValue(periodIndex - 1)
+			}
+			for _, name := range names {
+				point := &groupNamed(name).Points[periodIndex]
+				point.Completed += earned
+				if progress.InScope[periodIndex] {
+					point.Remaining += progress.ScopeSize[periodIndex] - progress.EarnedValue(periodIndex)
+				}
+			}
+		}
+	}
+
+	// Credit accumulates from the earned value of each period.
+	for _, group := range groups {
+		for periodIndex := range group.Points {
+			point := &group.Points[periodIndex]
+			if periodIndex > 0 {
+				point.Velocity = point.Completed
+				point.HasVelocity = true
+				point.Completed += group.Points[periodIndex-1].Completed
+			}
+		}
+		breakdown.Groups = append(breakdown.Groups, *group)
+	}
+
+	sort.Slice(breakdown.Groups, func(i, j int) bool {
+		iNone, jNone := breakdown.Groups[i].Name == NoGroup(dimension), breakdown.Groups[j].Name == NoGroup(dimension)
+		if iNone != jNone {
+			return jNone
+		}
+		return breakdown.Groups[i].Name < breakdown.Groups[j].Name
+	})
+
+	return breakdown, nil
+}
+
+// groupsByDimension picks how to find the groups an issue was in at the end of a date.
+func groupsByDimension(dimension string, teamOnDate func(issue *jira.Issue, date time.Time) string) (func(issue *jira.Issue, date time.Time) []string, error) {
+	switch dimension {
+	case config.BreakdownAssignee:
+		return func(issue *jira.Issue, date time.Time) []string {
+			return []string{issue.AssigneeOnDate(date)}
+		}, nil
+	case config.BreakdownTeam:
+		return func(issue *jira.Issue, date time.Time) []string {
+			return []string{teamOnDate(issue, date)}
+		}, nil
+	case config.BreakdownComponent:
+		return func(issue *jira.Issue, _ time.Time) []string {
+			return issue.GetComponents()
+		}, nil
+	}
+	return nil, errors.Errorf("unknown breakdown: %s", dimension)
+}
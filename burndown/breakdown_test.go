@@ -0,0 +1,80 @@
+package burndown
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go-burndown/config"
+	"go-burndown/jira"
+)
+
+func TestNewBreakdown(t *testing.T) {
+	rawIssues := []string{
+		// Half done by Ada, then reassigned to Grace who finished it.
+		`{"key": "A-1", "fields": {"created": "2024-12-20T10:00:00.000+0000", "status": {"name": "Done"}, "assignee": {"displayName": "Grace"}, "customfield_10016": 4},
+			"changelog": {"histories": [
+				{"created": "2025-01-02T10:00:00.000+0000", "items": [{"field": "Percent Complete", "toString": "0.5"}]},
+				{"created": "2025-01-08T10:00:00.000+0000", "items": [{"field": "assignee", "fromString": "Ada", "toString": "Grace"}]},
+				{"created": "2025-01-15T10:00:00.000+0000", "items": [{"field": "status", "fromString": "In Progress", "toString": "Done"}]}]}}`,
+		// Never assigned or started.
+		`{"key": "A-2", "fields": {"created": "2024-12-20T10:00:00.000+0000", "status": {"name": "To Do"}, "customfield_10016": 2}}`,
+	}
+	var issues []jira.Issue
+	for _, raw := range rawIssues {
+		issue, err := jira.ParseIssue([]byte(raw))
+		if err != nil {
+			t.Fatal(err)
+		}
+		issues = append(issues, *issue)
+	}
+
+	config := &config.Config{
+		MovingAvgWeeks: 4,
+		Jira: config.JiraConfig{
+			SizeField:            "customfield_10016",
+			PercentCompleteField: "Percent Complete",
+			DoneStatuses:         []string{"Done"},
+		},
+	}
+	timeline := Timeline{Periods: []Period{
+		{End: time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC)},
+		{End: time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)},
+		{End: time.Date(2025, 1, 17, 0, 0, 0, 0, time.UTC)},
+	}}
+	series, err := NewSeries(config, issues, timeline)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	breakdown, err := NewBreakdown(config, series, "assignee")
+	assert.NoError(t, err)
+
+	type expected struct {
+		name       string
+		completed  []float64
+		remaining  []float64
+		velocities []float64
+	}
+	var groups []expected
+	for _, group := range breakdown.Groups {
+		actual := expected{name: group.Name}
+		for _, point := range group.Points {
+			actual.completed = append(actual.completed, point.Completed)
+			actual.remaining = append(actual.remaining, point.Remaining)
+			if point.HasVelocity {
+				actual.velocities = append(actual.velocities, point.Velocity)
+			}
+		}
+		groups = append(groups, actual)
+	}
+	assert.Equal(t, []expected{
+		{name: "Ada", completed: []float64{2, 2, 2}, remaining: []float64{2, 0, 0}, velocities: []float64{0, 0}},
+		{name: "Grace", completed: []float64{0, 0, 2}, remaining: []float64{0, 2, 0}, velocities: []float64{0, 2}},
+		{name: "(No assignee)", completed: []float64{0, 0, 0}, remaining: []float64{2, 2, 2}, velocities: []float64{0, 0}},
+	}, groups)
+
+	_, err = NewBreakdown(config, series, "reporter")
+	assert.Error(t, err)
+}
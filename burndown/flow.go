@@ -0,0 +1,103 @@
+package burndown
+
+import (
+	"slices"
+
+	"github.com/pkg/errors"
+
+	"go-burndown/config"
+	"go-burndown/jira"
+)
+
+// CumulativeFlow is the work in each workflow status at the end of each period.
+type CumulativeFlow struct {
+	Timeline Timeline
+	// Statuses are in workflow order, first to last.
+	Statuses []string
+	Points   []FlowPoint
+}
+
+// FlowPoint is the work in each status at the end of a period.
+type FlowPoint struct {
+	Period Period
+	// Amounts is parallel to the statuses: the number of issues in each status, or their total size.
+	Amounts []float64
+}
+
+// NewCumulativeFlow replays each issue's status history to find how much work was in each status at the end of each period.
+// Statuses follow the configured order; any others follow by how early in an issue's history they occur, with done statuses last.
+// Issues that hadn't been created yet aren't counted.
+func NewCumulativeFlow(config *config.Config, issues []jira.Issue, timeline Timeline) (CumulativeFlow, error) {
+	flow := CumulativeFlow{Timeline: timeline}
+
+	// The status and measured amount of each issue at the end of each period.
+	statuses := make([][]string, len(timeline.Periods))
+	amounts := make([][]float64, len(timeline.Periods))
+	var seen []string
+	for periodIndex, period := range timeline.Periods {
+		statuses[periodIndex] = make([]string, len(issues))
+		amounts[periodIndex] = make([]float64, len(issues))
+		for i := range issues {
+			issue := &issues[i]
+			status, err := issue.StatusOnDate(period.End)
+			if err != nil {
+				return CumulativeFlow{}, errors.WithStack(err)
+			}
+			if status == "" {
+				continue
+			}
+			amount := 1.0
+			if config.CumulativeFlow.IsSizeMeasure() {
+				amount, err = issue.SizeOnDate(config, period.End)
+				if err != nil {
+					return CumulativeFlow{}, errors.WithStack(err)
+				}
+			}
+			statuses[periodIndex][i] = status
+			amounts[periodIndex][i] = amount
+			if !slices.Contains(seen, status) {
+				seen = append(seen, status)
+			}
+		}
+	}
+
+	// How many transitions into an issue's history each status first occurs, as a guess at the workflow order.
+	steps := map[string]int{}
+	for i := range issues {
+		for step, status := range issues[i].StatusHistory() {
+			if earliest, ok := steps[status]; !ok || step < earliest {
+				steps[status] = step
+			}
+		}
+	}
+	slices.SortStableFunc(seen, func(a, b string) int {
+		return steps[a] - steps[b]
+	})
+
+	flow.Statuses = append(flow.Statuses, config.CumulativeFlow.Statuses...)
+	var unlistedDone []string
+	for _, status := range seen {
+		if slices.Contains(flow.Statuses, status) {
+			continue
+		}
+		if config.IsDoneStatus(status) {
+			unlistedDone = append(unlistedDone, status)
+			continue
+		}
+		flow.Statuses = append(flow.Statuses, status)
+	}
+	flow.Statuses = append(flow.Statuses, unlistedDone...)
+
+	for periodIndex, period := range timeline.Periods {
+		point := FlowPoint{Period: period, Amounts: make([]float64, len(flow.Statuses))}
+		for i, status := range statuses[periodIndex] {
+			if status == "" {
+				continue
+			}
+			point.Amounts[slices.Index(flow.Statuses, status)] += amounts[periodIndex][i]
+		}
+		flow.Points = append(flow.Points, point)
+	}
+
+	return flow, nil
+}
@@ -0,0 +1,79 @@
+package burndown
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go-burndown/config"
+	"go-burndown/jira"
+)
+
+func TestNewCumulativeFlow(t *testing.T) {
+	rawIssues := []string{
+		// Created before the start and finished in the second period.
+		`{"key": "A-1", "fields": {"created": "2024-12-20T10:00:00.000+0000", "status": {"name": "Done"}, "customfield_10016": 5},
+			"changelog": {"histories": [
+				{"created": "2025-01-02T10:00:00.000+0000", "items": [{"field": "status", "fromString": "To Do", "toString": "In Progress"}]},
+				{"created": "2025-01-09T10:00:00.000+0000", "items": [{"field": "status", "fromString": "In Progress", "toString": "Done"}]}]}}`,
+		// Created in the second period and never started.
+		`{"key": "A-2", "fields": {"created": "2025-01-08T10:00:00.000+0000", "status": {"name": "Backlog"}, "customfield_10016": 3}}`,
+	}
+	var issues []jira.Issue
+	for _, raw := range rawIssues {
+		issue, err := jira.ParseIssue([]byte(raw))
+		if err != nil {
+			t.Fatal(err)
+		}
+		issues = append(issues, *issue)
+	}
+
+	timeline := Timeline{Periods: []Period{
+		{End: time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC)},
+		{End: time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)},
+	}}
+
+	tests := []struct {
+		name     string
+		flow     config.FlowConfig
+		statuses []string
+		amounts  [][]float64
+	}{
+		{
+			name:     "counts issues in workflow order, done last",
+			statuses: []string{"Backlog", "In Progress", "Done"},
+			amounts:  [][]float64{{0, 1, 0}, {1, 0, 1}},
+		},
+		{
+			name:     "configured order first",
+			flow:     config.FlowConfig{Statuses: []string{"To Do", "Backlog", "In Progress"}},
+			statuses: []string{"To Do", "Backlog", "In Progress", "Done"},
+			amounts:  [][]float64{{0, 0, 1, 0}, {0, 1, 0, 1}},
+		},
+		{
+			name:     "totals sizes",
+			flow:     config.FlowConfig{Measure: config.FlowMeasureSize},
+			statuses: []string{"Backlog", "In Progress", "Done"},
+			amounts:  [][]float64{{0, 5, 0}, {3, 0, 5}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &config.Config{
+				CumulativeFlow: tt.flow,
+				Jira: config.JiraConfig{
+					SizeField:    "customfield_10016",
+					DoneStatuses: []string{"Done"},
+				},
+			}
+			flow, err := NewCumulativeFlow(config, issues, timeline)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.statuses, flow.Statuses)
+			for periodIndex, point := range flow.Points {
+				assert.Equal(t, tt.amounts[periodIndex], point.Amounts)
+			}
+		})
+	}
+}
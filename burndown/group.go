@@ -0,0 +1,54 @@
+package burndown
+
+import (
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"go-burndown/config"
+	"go-burndown/jira"
+)
+
+// GroupSeries is the burndown of one group of issues, such as the issues with a label.
+type GroupSeries struct {
+	Name   string
+	Series Series
+}
+
+// NewGroupedSeries splits the issues by the configured group by field and computes a burndown for each group,
+// ordered by name with the issues in no group last. An issue in several groups counts toward each of them.
+func NewGroupedSeries(config *config.Config, issues []jira.Issue, timeline Timeline) ([]GroupSeries, error) {
+	noGroup := NoGroup(config.GroupBy)
+	issuesByGroup := map[string][]jira.Issue{}
+	for i := range issues {
+		issue := &issues[i]
+		groups := issue.GetGroups(config.GroupBy)
+		if len(groups) == 0 {
+			groups = []string{noGroup}
+		}
+		for _, group := range groups {
+			issuesByGroup[group] = append(issuesByGroup[group], *issue)
+		}
+	}
+
+	var names []string
+	for name := range issuesByGroup {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if (names[i] == noGroup) != (names[j] == noGroup) {
+			return names[j] == noGroup
+		}
+		return names[i] < names[j]
+	})
+
+	var grouped []GroupSeries
+	for _, name := range names {
+		series, err := NewSeries(config, issuesByGroup[name], timeline)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		grouped = append(grouped, GroupSeries{Name: name, Series: series})
+	}
+	return grouped, nil
+}
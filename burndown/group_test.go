@@ -0,0 +1,49 @@
+package burndown
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go-burndown/config"
+	"go-burndown/jira"
+)
+
+func TestNewGroupedSeries(t *testing.T) {
+	rawIssues := []string{
+		`{"key": "A-1", "fields": {"status": {"name": "Done"}, "labels": ["web", "api"], "customfield_10016": 3}}`,
+		`{"key": "A-2", "fields": {"status": {"name": "To Do"}, "labels": ["api"], "customfield_10016": 5}}`,
+		`{"key": "A-3", "fields": {"status": {"name": "To Do"}, "customfield_10016": 2}}`,
+	}
+	var issues []jira.Issue
+	for _, raw := range rawIssues {
+		issue, err := jira.ParseIssue([]byte(raw))
+		if err != nil {
+			t.Fatal(err)
+		}
+		issues = append(issues, *issue)
+	}
+
+	config := &config.Config{
+		MovingAvgWeeks: 4,
+		GroupBy:        "labels",
+		Jira: config.JiraConfig{
+			SizeField:    "customfield_10016",
+			DoneStatuses: []string{"Done"},
+		},
+	}
+	timeline := Timeline{Periods: []Period{{End: time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC)}}}
+
+	grouped, err := NewGroupedSeries(config, issues, timeline)
+	assert.NoError(t, err)
+
+	scopes := map[string]float64{}
+	var names []string
+	for _, group := range grouped {
+		names = append(names, group.Name)
+		scopes[group.Name] = group.Series.Points[0].Scope
+	}
+	assert.Equal(t, []string{"api", "web", "(No labels)"}, names)
+	assert.Equal(t, map[string]float64{"api": 8, "web": 3, "(No labels)": 2}, scopes)
+}
@@ -0,0 +1,130 @@
+// Package burndown computes the reporting periods that issue progress is bucketed into.
+package burndown
+
+import (
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"go-burndown/config"
+	"go-burndown/jira"
+)
+
+// Period is a single reporting bucket. Progress is measured as of the end of the End day.
+type Period struct {
+	Start time.Time
+	End   time.Time
+	// Sprint is the sprint this period covers, if periods are sprint-aligned.
+	Sprint *jira.Sprint
+}
+
+// Timeline is the ordered sequence of reporting periods a report is bucketed into, oldest first.
+type Timeline struct {
+	Periods []Period
+	// Unit abbreviates the period length in headers, e.g. "w" in "Avg (12w)".
+	Unit string
+	// WorkdaysPerPeriod converts a velocity per period into workdays for projections.
+	WorkdaysPerPeriod float64
+}
+
+// NewCalendarTimeline builds the periods from the configured start date up to now.
+func NewCalendarTimeline(config *config.Config, now time.Time) (Timeline, error) {
+	startDate, err := time.Parse("2006-01-02", config.StartDate)
+	if err != nil {
+		return Timeline{}, errors.Wrapf(err, "invalid start date format: %s", config.StartDate)
+	}
+	return calendarTimeline(config.PeriodOrDefault(), config.PeriodAnchor, startDate, now)
+}
+
+// calendarTimeline steps from the start date by the period length up to now.
+func calendarTimeline(period, anchor string, startDate, now time.Time) (Timeline, error) {
+	var timeline Timeline
+	var dates []time.Time
+
+	switch period {
+	case config.PeriodDaily:
+		// Daily periods are workdays, so that one period is one workday in projections.
+		timeline.Unit = "d"
+		timeline.WorkdaysPerPeriod = 1
+		for d := startDate; !d.After(now); d = d.AddDate(0, 0, 1) {
+			if isWorkday(d) {
+				dates = append(dates, d)
+			}
+		}
+
+	case config.PeriodWeekly, config.PeriodBiweekly:
+		days := 7
+		timeline.Unit = "w"
+		timeline.WorkdaysPerPeriod = 5
+		if period == config.PeriodBiweekly {
+			days = 14
+			timeline.Unit = "2w"
+			timeline.WorkdaysPerPeriod = 10
+		}
+		first, err := anchorDate(startDate, anchor)
+		if err != nil {
+			return Timeline{}, err
+		}
+		for d := first; !d.After(now); d = d.AddDate(0, 0, days) {
+			dates = append(dates, d)
+		}
+
+	case config.PeriodMonthly:
+		timeline.Unit = "m"
+		timeline.WorkdaysPerPeriod = 21.75 // 261 workdays a year over 12 months.
+		for i := 0; ; i++ {
+			d := addMonthsClamped(startDate, i)
+			if d.After(now) {
+				break
+			}
+			dates = append(dates, d)
+		}
+
+	case config.PeriodSprint:
+		return Timeline{}, errors.New("sprint periods come from the board's sprints, not the calendar")
+
+	default:
+		return Timeline{}, errors.Errorf("unknown period: %s", period)
+	}
+
+	timeline.Periods = periodsEndingOn(dates)
+
+	return timeline, nil
+}
+
+// periodsEndingOn turns the measurement dates into periods, each starting the day after the previous one ends.
+func periodsEndingOn(dates []time.Time) []Period {
+	periods := make([]Period, 0, len(dates))
+	for i, end := range dates {
+		start := end
+		if i > 0 {
+			start = dates[i-1].AddDate(0, 0, 1)
+		}
+		periods = append(periods, Period{Start: start, End: end})
+	}
+	return periods
+}
+
+// anchorDate moves the start date forward to the first anchor weekday on or after it.
+func anchorDate(startDate time.Time, anchor string) (time.Time, error) {
+	if anchor == "" {
+		return startDate, nil
+	}
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if strings.EqualFold(day.String(), anchor) {
+			offset := (int(day) - int(startDate.Weekday()) + 7) % 7
+			return startDate.AddDate(0, 0, offset), nil
+		}
+	}
+	return time.Time{}, errors.Errorf("unknown period anchor: %s", anchor)
+}
+
+// addMonthsClamped adds months to a date, clamping the day to the end of shorter months
+// (so Jan 31 is followed by Feb 28 rather than Mar 3).
+func addMonthsClamped(date time.Time, months int) time.Time {
+	firstOfMonth := time.Date(date.Year(), date.Month()+time.Month(months), 1, 0, 0, 0, 0, date.Location())
+	lastDay := firstOfMonth.AddDate(0, 1, -1).Day()
+	day := min(date.Day(), lastDay)
+	return time.Date(firstOfMonth.Year(), firstOfMonth.Month(), day, 0, 0, 0, 0, date.Location())
+}
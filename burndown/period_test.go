@@ -0,0 +1,101 @@
+package burndown
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go-burndown/config"
+)
+
+func TestNewCalendarTimeline(t *testing.T) {
+	date := func(value string) time.Time {
+		parsed, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return parsed
+	}
+
+	tests := []struct {
+		name              string
+		config            config.Config
+		now               time.Time
+		ends              []time.Time
+		unit              string
+		workdaysPerPeriod float64
+		errMessage        string
+	}{
+		{
+			name:              "weekly by default",
+			config:            config.Config{StartDate: "2025-01-01"},
+			now:               date("2025-01-15"),
+			ends:              []time.Time{date("2025-01-01"), date("2025-01-08"), date("2025-01-15")},
+			unit:              "w",
+			workdaysPerPeriod: 5,
+		},
+		{
+			name:              "weekly anchored to friday",
+			config:            config.Config{StartDate: "2025-01-01", Period: config.PeriodWeekly, PeriodAnchor: "friday"},
+			now:               date("2025-01-16"),
+			ends:              []time.Time{date("2025-01-03"), date("2025-01-10")},
+			unit:              "w",
+			workdaysPerPeriod: 5,
+		},
+		{
+			name:              "biweekly",
+			config:            config.Config{StartDate: "2025-01-01", Period: config.PeriodBiweekly},
+			now:               date("2025-01-29"),
+			ends:              []time.Time{date("2025-01-01"), date("2025-01-15"), date("2025-01-29")},
+			unit:              "2w",
+			workdaysPerPeriod: 10,
+		},
+		{
+			name:              "daily skips weekends",
+			config:            config.Config{StartDate: "2025-01-03", Period: config.PeriodDaily},
+			now:               date("2025-01-07"),
+			ends:              []time.Time{date("2025-01-03"), date("2025-01-06"), date("2025-01-07")},
+			unit:              "d",
+			workdaysPerPeriod: 1,
+		},
+		{
+			name:              "monthly clamps to short months",
+			config:            config.Config{StartDate: "2025-01-31", Period: config.PeriodMonthly},
+			now:               date("2025-04-01"),
+			ends:              []time.Time{date("2025-01-31"), date("2025-02-28"), date("2025-03-31")},
+			unit:              "m",
+			workdaysPerPeriod: 21.75,
+		},
+		{
+			name:       "malformed start date",
+			config:     config.Config{StartDate: "01-01-2025"},
+			now:        date("2025-01-15"),
+			errMessage: "invalid start date format",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			timeline, err := NewCalendarTimeline(&tt.config, tt.now)
+			if tt.errMessage != "" {
+				assert.ErrorContains(t, err, tt.errMessage, `expected error`)
+				return
+			}
+			assert.NoError(t, err, `expected no errors`)
+
+			var ends []time.Time
+			for _, period := range timeline.Periods {
+				ends = append(ends, period.End)
+			}
+			assert.Equal(t, tt.ends, ends)
+			assert.Equal(t, tt.unit, timeline.Unit)
+			assert.InDelta(t, tt.workdaysPerPeriod, timeline.WorkdaysPerPeriod, 0.001)
+
+			// Each period starts the day after the previous one ends.
+			for i := 1; i < len(timeline.Periods); i++ {
+				assert.Equal(t, timeline.Periods[i-1].End.AddDate(0, 0, 1), timeline.Periods[i].Start)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package burndown
+
+import (
+	"go-burndown/jira"
+)
+
+const (
+	// ScopeAdded is an issue that came into scope, by being created or leaving a removed status.
+	ScopeAdded = "Added"
+	// ScopeRemoved is an issue that left scope by moving to a removed status.
+	ScopeRemoved = "Removed"
+	// ScopeReestimated is an issue in scope whose size changed.
+	ScopeReestimated = "Re-estimated"
+)
+
+// ScopeChange is one issue's contribution to the change in scope during a period.
+type ScopeChange struct {
+	Issue *jira.Issue
+	Kind  string
+	// Before and After are the size the issue counted for at the end of the previous period and this one.
+	Before float64
+	After  float64
+}
+
+// Delta is how much the change grew the scope; negative if it shrank.
+func (change ScopeChange) Delta() float64 {
+	return change.After - change.Before
+}
+
+// addScopeChanges accounts for how each issue changed the scope since the previous period.
+func (point *Point) addScopeChanges(issues []IssueProgress, periodIndex int) {
+	for i := range issues {
+		progress := &issues[i]
+		change := ScopeChange{
+			Issue:  progress.Issue,
+			Before: progress.ScopeSize[periodIndex-1],
+			After:  progress.ScopeSize[periodIndex],
+		}
+		wasInScope, isInScope := progress.InScope[periodIndex-1], progress.InScope[periodIndex]
+		switch {
+		case !wasInScope && isInScope:
+			change.Kind = ScopeAdded
+			point.Added += change.After
+		case wasInScope && !isInScope:
+			change.Kind = ScopeRemoved
+			point.Removed += change.Before
+		case isInScope && change.Delta() != 0:
+			change.Kind = ScopeReestimated
+			point.Reestimated += change.Delta()
+		default:
+			continue
+		}
+		point.ScopeChanges = append(point.ScopeChanges, change)
+	}
+}
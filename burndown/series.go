@@ -0,0 +1,188 @@
+package burndown
+
+import (
+	"math"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"go-burndown/config"
+	"go-burndown/jira"
+)
+
+// Point is the burndown at the end of one period, mirroring a row of the Projections sheet.
+type Point struct {
+	Period    Period
+	Completed float64
+	// Remaining is the scope less the work completed.
+	Remaining float64
+	// Scope is the total size of the issues in scope at the end of the period.
+	Scope float64
+	// Added, Removed and Reestimated account for the change in scope since the previous period.
+	// Removed is positive; Reestimated is the net change in size of issues that stayed in scope.
+	Added        float64
+	Removed      float64
+	Reestimated  float64
+	ScopeChanges []ScopeChange
+	// Velocity is the work completed during the period. The first period has none.
+	Velocity    float64
+	HasVelocity bool
+	// AvgVelocity is the moving average of velocity, once there are two velocities.
+	AvgVelocity float64
+	HasAvg      bool
+	// StdDev is the sample standard deviation of velocity over the same window, once there are three velocities.
+	StdDev    float64
+	HasStdDev bool
+}
+
+// Forecast is the projected completion dates at the end of one period. A zero date means there is no projection.
+type Forecast struct {
+	Fast time.Time // At the average velocity plus one standard deviation (p68).
+	Mean time.Time
+	Slow time.Time // At the average velocity minus one standard deviation (p68).
+}
+
+// IssueProgress is one issue's progress at the end of every period, mirroring a row of the Work sheet.
+type IssueProgress struct {
+	Issue *jira.Issue
+	Size  float64
+	// PercentComplete is parallel to the timeline's periods, 0.0 (0%) to 1.0 (100%).
+	PercentComplete []float64
+	// InScope and ScopeSize are parallel to the timeline's periods: whether the issue counted toward scope
+	// at the end of each period, and the size it counted for then (zero when out of scope).
+	InScope   []bool
+	ScopeSize []float64
+}
+
+// EarnedValue is the work the issue had completed at the end of a period, against the size it counted for in scope
+// then. Issues out of scope have earned nothing, so completed work never exceeds scope.
+func (progress *IssueProgress) EarnedValue(periodIndex int) float64 {
+	return progress.PercentComplete[periodIndex] * progress.ScopeSize[periodIndex]
+}
+
+// Series is the burndown over every period of a timeline.
+type Series struct {
+	Timeline Timeline
+	Issues   []IssueProgress
+	Points   []Point
+}
+
+// NewSeries computes the burndown of the issues over the timeline, averaging velocity over the configured window.
+func NewSeries(config *config.Config, issues []jira.Issue, timeline Timeline) (Series, error) {
+	series := Series{Timeline: timeline}
+
+	for i := range issues {
+		issue := &issues[i]
+		progress := IssueProgress{
+			Issue:           issue,
+			Size:            issue.GetSize(config),
+			PercentComplete: make([]float64, len(timeline.Periods)),
+			InScope:         make([]bool, len(timeline.Periods)),
+			ScopeSize:       make([]float64, len(timeline.Periods)),
+		}
+		for periodIndex, period := range timeline.Periods {
+			percentComplete, err := issue.PercentCompleteOnDate(config, period.End)
+			if err != nil {
+				return Series{}, errors.WithStack(err)
+			}
+			progress.PercentComplete[periodIndex] = percentComplete
+
+			inScope, err := issue.InScopeOnDate(config, period.End)
+			if err != nil {
+				return Series{}, errors.WithStack(err)
+			}
+			if inScope {
+				size, err := issue.SizeOnDate(config, period.End)
+				if err != nil {
+					return Series{}, errors.WithStack(err)
+				}
+				progress.InScope[periodIndex] = true
+				progress.ScopeSize[periodIndex] = size
+			}
+		}
+		series.Issues = append(series.Issues, progress)
+	}
+
+	var velocities []float64
+	for periodIndex, period := range timeline.Periods {
+		completed, scope := 0.0, 0.0
+		for i := range series.Issues {
+			completed += series.Issues[i].EarnedValue(periodIndex)
+			scope += series.Issues[i].ScopeSize[periodIndex]
+		}
+
+		point := Point{
+			Period:    period,
+			Completed: completed,
+			Remaining: scope - completed,
+			Scope:     scope,
+		}
+		if periodIndex > 0 {
+			point.addScopeChanges(series.Issues, periodIndex)
+		}
+
+		if periodIndex > 0 {
+			point.Velocity = completed - series.Points[periodIndex-1].Completed
+			point.HasVelocity = true
+			velocities = append(velocities, point.Velocity)
+		}
+		if periodIndex > 1 {
+			point.AvgVelocity, point.StdDev = VelocityStats(velocities, config.MovingAvgWeeks)
+			point.HasAvg = true
+			point.HasStdDev = periodIndex > 2
+		}
+
+		series.Points = append(series.Points, point)
+	}
+
+	return series, nil
+}
+
+// Forecast projects the completion dates from a period, mirroring the Projections sheet.
+// There is no forecast until the period has a standard deviation of velocity.
+func (series *Series) Forecast(periodIndex int) (forecast Forecast, ok bool) {
+	point := series.Points[periodIndex]
+	if !point.HasStdDev {
+		return Forecast{}, false
+	}
+	workdaysPerPeriod := series.Timeline.WorkdaysPerPeriod
+	forecast.Fast, _ = ProjectDate(point.Period.End, point.Remaining, point.AvgVelocity+point.StdDev, workdaysPerPeriod)
+	forecast.Mean, _ = ProjectDate(point.Period.End, point.Remaining, point.AvgVelocity, workdaysPerPeriod)
+	forecast.Slow, _ = ProjectDate(point.Period.End, point.Remaining, point.AvgVelocity-point.StdDev, workdaysPerPeriod)
+	return forecast, true
+}
+
+// Velocities returns the velocities of the periods up to and including the period, oldest first.
+func (series *Series) Velocities(periodIndex int) (velocities []float64) {
+	for _, point := range series.Points[:periodIndex+1] {
+		if point.HasVelocity {
+			velocities = append(velocities, point.Velocity)
+		}
+	}
+	return velocities
+}
+
+// VelocityStats computes the average and sample standard deviation of the most recent velocities in the window,
+// matching the Projections sheet's AVERAGE and STDEV over OFFSET. The standard deviation needs two velocities.
+func VelocityStats(velocities []float64, window uint) (avg, stdDev float64) {
+	n := min(len(velocities), int(window))
+	if n == 0 {
+		return 0, 0
+	}
+	recent := velocities[len(velocities)-n:]
+
+	for _, velocity := range recent {
+		avg += velocity
+	}
+	avg /= float64(n)
+
+	if n < 2 {
+		return avg, 0
+	}
+	for _, velocity := range recent {
+		stdDev += (velocity - avg) * (velocity - avg)
+	}
+	stdDev = math.Sqrt(stdDev / float64(n-1))
+
+	return avg, stdDev
+}
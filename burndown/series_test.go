@@ -0,0 +1,186 @@
+package burndown
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go-burndown/config"
+	"go-burndown/jira"
+)
+
+func TestVelocityStats(t *testing.T) {
+	tests := []struct {
+		name       string
+		velocities []float64
+		window     uint
+		avg        float64
+		stdDev     float64
+	}{
+		{
+			name:       "no velocities",
+			velocities: nil,
+			window:     4,
+		},
+		{
+			name:       "single velocity has no deviation",
+			velocities: []float64{3},
+			window:     4,
+			avg:        3,
+		},
+		{
+			name:       "fewer velocities than the window",
+			velocities: []float64{2, 4, 6},
+			window:     4,
+			avg:        4,
+			stdDev:     2,
+		},
+		{
+			name:       "only the most recent velocities in the window",
+			velocities: []float64{100, 2, 4, 6},
+			window:     3,
+			avg:        4,
+			stdDev:     2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			avg, stdDev := VelocityStats(tt.velocities, tt.window)
+			assert.InDelta(t, tt.avg, avg, 0.0001)
+			assert.InDelta(t, tt.stdDev, stdDev, 0.0001)
+		})
+	}
+}
+
+func TestProjectDate(t *testing.T) {
+	friday := time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		remaining float64
+		velocity  float64
+		projected time.Time
+		ok        bool
+	}{
+		{
+			name:      "skips the weekend",
+			remaining: 2,
+			velocity:  10, // One workday of a five workday period.
+			projected: time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC),
+			ok:        true,
+		},
+		{
+			name:      "rounds up partial workdays",
+			remaining: 10,
+			velocity:  4, // 12.5 workdays.
+			projected: time.Date(2025, 1, 22, 0, 0, 0, 0, time.UTC),
+			ok:        true,
+		},
+		{
+			name:      "already done",
+			remaining: 0,
+			velocity:  4,
+			projected: friday,
+			ok:        true,
+		},
+		{
+			name:      "no progress",
+			remaining: 10,
+			velocity:  0,
+			ok:        false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			projected, ok := ProjectDate(friday, tt.remaining, tt.velocity, 5)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.projected, projected)
+		})
+	}
+}
+
+func TestNewSeries(t *testing.T) {
+	config := &config.Config{
+		MovingAvgWeeks: 4,
+		Jira: config.JiraConfig{
+			SizeField:            "customfield_10016",
+			PercentCompleteField: "Percent Complete",
+			DoneStatuses:         []string{"Done"},
+			RemovedStatuses:      []string{"Won't Do"},
+		},
+	}
+	timeline := Timeline{Periods: []Period{
+		{End: time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC)},
+		{End: time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)},
+		{End: time.Date(2025, 1, 17, 0, 0, 0, 0, time.UTC)},
+	}}
+
+	tests := []struct {
+		name        string
+		issue       string
+		earnedValue []float64
+		completed   []float64
+		remaining   []float64
+		scope       []float64
+	}{
+		{
+			name: "progress",
+			issue: `{"key": "A-1", "fields": {"created": "2024-12-20T10:00:00.000+0000", "status": {"name": "In Progress"}, "customfield_10016": 4},
+				"changelog": {"histories": [
+					{"created": "2025-01-02T10:00:00.000+0000", "items": [{"field": "Percent Complete", "toString": "0.25"}]},
+					{"created": "2025-01-09T10:00:00.000+0000", "items": [{"field": "Percent Complete", "toString": "0.5"}]}]}}`,
+			earnedValue: []float64{1, 2, 2},
+			completed:   []float64{1, 2, 2},
+			remaining:   []float64{3, 2, 2},
+			scope:       []float64{4, 4, 4},
+		},
+		{
+			name: "re-estimated after progress",
+			issue: `{"key": "A-1", "fields": {"created": "2024-12-20T10:00:00.000+0000", "status": {"name": "In Progress"}, "customfield_10016": 8},
+				"changelog": {"histories": [
+					{"created": "2025-01-02T10:00:00.000+0000", "items": [{"field": "Percent Complete", "toString": "0.5"}]},
+					{"created": "2025-01-09T10:00:00.000+0000", "items": [{"field": "Story Points", "fieldId": "customfield_10016", "fromString": "4", "toString": "8"}]}]}}`,
+			earnedValue: []float64{2, 4, 4},
+			completed:   []float64{2, 4, 4},
+			remaining:   []float64{2, 4, 4},
+			scope:       []float64{4, 8, 8},
+		},
+		{
+			name: "removed after progress",
+			issue: `{"key": "A-1", "fields": {"created": "2024-12-20T10:00:00.000+0000", "status": {"name": "Won't Do"}, "customfield_10016": 4},
+				"changelog": {"histories": [
+					{"created": "2025-01-02T10:00:00.000+0000", "items": [{"field": "Percent Complete", "toString": "0.5"}]},
+					{"created": "2025-01-09T10:00:00.000+0000", "items": [{"field": "status", "fromString": "In Progress", "toString": "Won't Do"}]}]}}`,
+			earnedValue: []float64{2, 0, 0},
+			completed:   []float64{2, 0, 0},
+			remaining:   []float64{2, 0, 0},
+			scope:       []float64{4, 0, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issue, err := jira.ParseIssue([]byte(tt.issue))
+			if err != nil {
+				t.Fatal(err)
+			}
+			series, err := NewSeries(config, []jira.Issue{*issue}, timeline)
+			assert.NoError(t, err)
+
+			var earnedValue, completed, remaining, scope []float64
+			for periodIndex, point := range series.Points {
+				earnedValue = append(earnedValue, series.Issues[0].EarnedValue(periodIndex))
+				completed = append(completed, point.Completed)
+				remaining = append(remaining, point.Remaining)
+				scope = append(scope, point.Scope)
+			}
+			assert.Equal(t, tt.earnedValue, earnedValue)
+			assert.Equal(t, tt.completed, completed)
+			assert.Equal(t, tt.remaining, remaining)
+			assert.Equal(t, tt.scope, scope)
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package burndown
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+
+	"go-burndown/config"
+	"go-burndown/jira"
+)
+
+// SprintCommitment is how much work a sprint started with and how much of its work was done by its end.
+type SprintCommitment struct {
+	Committed float64
+	Completed float64
+}
+
+// NewSprintTimeline builds one period per sprint, ending when the sprint ended, for sprints ending on or after the start date.
+// An active sprint is measured up to now.
+func NewSprintTimeline(config *config.Config, sprints []jira.Sprint, now time.Time) (Timeline, error) {
+	startDate, err := time.Parse("2006-01-02", config.StartDate)
+	if err != nil {
+		return Timeline{}, errors.Wrapf(err, "invalid start date format: %s", config.StartDate)
+	}
+	today := dateOf(now)
+
+	timeline := Timeline{Unit: "s"}
+	totalWorkdays := 0
+	for i := range sprints {
+		sprint := &sprints[i]
+		start := dateOf(sprint.Start())
+		end := dateOf(sprint.End())
+		if end.Before(startDate) {
+			continue
+		}
+		if end.After(today) {
+			end = today
+		}
+		timeline.Periods = append(timeline.Periods, Period{Start: start, End: end, Sprint: sprint})
+		totalWorkdays += workdaysAfter(dateOf(sprint.Start()), dateOf(sprint.End()))
+	}
+
+	// Sprints vary in length, so projections use the average sprint length.
+	timeline.WorkdaysPerPeriod = 10
+	if totalWorkdays > 0 {
+		timeline.WorkdaysPerPeriod = float64(totalWorkdays) / float64(len(timeline.Periods))
+	}
+
+	return timeline, nil
+}
+
+// CommitmentForSprint totals the size of the issues in the sprint when it started (committed),
+// and the size of the issues in the sprint when it ended that were done by then (completed).
+// Sizes are as they were on those dates, so later re-estimates don't rewrite past sprints.
+func CommitmentForSprint(config *config.Config, issues []jira.Issue, sprint *jira.Sprint) (commitment SprintCommitment, err error) {
+	for i := range issues {
+		issue := &issues[i]
+
+		if issue.InSprintAt(config, sprint.ID, sprint.Start()) {
+			size, err := issue.SizeOnDate(config, dateOf(sprint.Start()))
+			if err != nil {
+				return SprintCommitment{}, errors.WithStack(err)
+			}
+			commitment.Committed += size
+		}
+
+		if issue.InSprintAt(config, sprint.ID, sprint.End()) {
+			percentComplete, err := issue.PercentCompleteOnDate(config, dateOf(sprint.End()))
+			if err != nil {
+				return SprintCommitment{}, errors.WithStack(err)
+			}
+			if percentComplete >= 1.0 {
+				size, err := issue.SizeOnDate(config, dateOf(sprint.End()))
+				if err != nil {
+					return SprintCommitment{}, errors.WithStack(err)
+				}
+				commitment.Completed += size
+			}
+		}
+	}
+	return commitment, nil
+}
+
+// dateOf truncates a moment to its calendar date, matching dates parsed from the config.
+func dateOf(moment time.Time) time.Time {
+	return time.Date(moment.Year(), moment.Month(), moment.Day(), 0, 0, 0, 0, time.UTC)
+}
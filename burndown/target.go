@@ -0,0 +1,58 @@
+package burndown
+
+import (
+	"math"
+	"time"
+)
+
+// TargetOutlook is whether a target date can be met as of the end of one period, mirroring a row of the Targets sheet.
+type TargetOutlook struct {
+	// RequiredVelocity is the velocity needed to finish the remaining work by the target. There is none once the target has passed.
+	RequiredVelocity    float64
+	HasRequiredVelocity bool
+	// Cut is the scope to drop to finish by the target at the average velocity, once there is an average.
+	Cut    float64
+	HasCut bool
+	// Probability is the chance of finishing by the target, treating velocity as normally distributed,
+	// once there is a standard deviation of velocity.
+	Probability    float64
+	HasProbability bool
+}
+
+// TargetOutlook works out whether the target date can be met from a period, mirroring the Targets sheet's formulas.
+func (series *Series) TargetOutlook(periodIndex int, target time.Time) (outlook TargetOutlook) {
+	point := series.Points[periodIndex]
+
+	// Periods left until the target, counted in workdays the same way the projections do.
+	periodsLeft := float64(workdaysAfter(point.Period.End, target)) / series.Timeline.WorkdaysPerPeriod
+
+	if periodsLeft > 0 {
+		outlook.RequiredVelocity = math.Max(0, point.Remaining) / periodsLeft
+		outlook.HasRequiredVelocity = true
+	}
+
+	if point.HasAvg {
+		outlook.Cut = math.Max(0, point.Remaining-point.AvgVelocity*math.Max(0, periodsLeft))
+		outlook.HasCut = true
+	}
+
+	if point.HasStdDev {
+		outlook.HasProbability = true
+		switch {
+		case point.Remaining <= 0:
+			outlook.Probability = 1
+		case periodsLeft <= 0:
+			outlook.Probability = 0
+		case point.StdDev == 0:
+			if point.AvgVelocity >= outlook.RequiredVelocity {
+				outlook.Probability = 1
+			}
+		default:
+			// The chance that velocity is at least the required velocity: 1-NORMDIST(required, avg, stdDev, TRUE).
+			z := (outlook.RequiredVelocity - point.AvgVelocity) / point.StdDev
+			outlook.Probability = 1 - 0.5*math.Erfc(-z/math.Sqrt2)
+		}
+	}
+
+	return outlook
+}
@@ -0,0 +1,68 @@
+package burndown
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTargetOutlook(t *testing.T) {
+	friday := time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC)
+	twoWeeksOut := time.Date(2025, 1, 17, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		point   Point
+		target  time.Time
+		outlook TargetOutlook
+	}{
+		{
+			name:    "no average yet",
+			point:   Point{Remaining: 20},
+			target:  twoWeeksOut,
+			outlook: TargetOutlook{RequiredVelocity: 10, HasRequiredVelocity: true},
+		},
+		{
+			name:    "average velocity short of the target",
+			point:   Point{Remaining: 20, AvgVelocity: 8, HasAvg: true},
+			target:  twoWeeksOut,
+			outlook: TargetOutlook{RequiredVelocity: 10, HasRequiredVelocity: true, Cut: 4, HasCut: true},
+		},
+		{
+			name:    "required velocity is the average",
+			point:   Point{Remaining: 20, AvgVelocity: 10, HasAvg: true, StdDev: 2, HasStdDev: true},
+			target:  twoWeeksOut,
+			outlook: TargetOutlook{RequiredVelocity: 10, HasRequiredVelocity: true, HasCut: true, Probability: 0.5, HasProbability: true},
+		},
+		{
+			name:    "required velocity one deviation above the average",
+			point:   Point{Remaining: 24, AvgVelocity: 10, HasAvg: true, StdDev: 2, HasStdDev: true},
+			target:  twoWeeksOut,
+			outlook: TargetOutlook{RequiredVelocity: 12, HasRequiredVelocity: true, Cut: 4, HasCut: true, Probability: 0.1587, HasProbability: true},
+		},
+		{
+			name:    "target passed",
+			point:   Point{Remaining: 20, AvgVelocity: 10, HasAvg: true, StdDev: 2, HasStdDev: true},
+			target:  friday,
+			outlook: TargetOutlook{Cut: 20, HasCut: true, HasProbability: true},
+		},
+		{
+			name:    "done",
+			point:   Point{Remaining: 0, AvgVelocity: 10, HasAvg: true, StdDev: 2, HasStdDev: true},
+			target:  friday,
+			outlook: TargetOutlook{HasCut: true, Probability: 1, HasProbability: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.point.Period = Period{End: friday}
+			series := Series{Timeline: Timeline{WorkdaysPerPeriod: 5}, Points: []Point{tt.point}}
+			outlook := series.TargetOutlook(0, tt.target)
+			assert.InDelta(t, tt.outlook.Probability, outlook.Probability, 0.0001)
+			outlook.Probability = tt.outlook.Probability
+			assert.Equal(t, tt.outlook, outlook)
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package burndown
+
+import (
+	"math"
+	"time"
+)
+
+// isWorkday checks if the date is a Monday through Friday, matching Excel's WORKDAY.
+func isWorkday(date time.Time) bool {
+	return date.Weekday() != time.Saturday && date.Weekday() != time.Sunday
+}
+
+// workdaysAfter counts the workdays after start up to and including end, matching Excel's NETWORKDAYS(start+1, end).
+func workdaysAfter(start, end time.Time) (workdays int) {
+	for d := start.AddDate(0, 0, 1); !d.After(end); d = d.AddDate(0, 0, 1) {
+		if isWorkday(d) {
+			workdays++
+		}
+	}
+	return workdays
+}
+
+// addWorkdays moves the date forward by a number of workdays, matching Excel's WORKDAY.
+func addWorkdays(date time.Time, workdays int) time.Time {
+	for workdays > 0 {
+		date = date.AddDate(0, 0, 1)
+		if isWorkday(date) {
+			workdays--
+		}
+	}
+	return date
+}
+
+// ProjectDate projects when the remaining work will be done at a velocity per period,
+// matching the Projections sheet's WORKDAY(date, CEILING((remaining/velocity)*workdays, 1)).
+// There is no projection if the velocity isn't positive.
+func ProjectDate(date time.Time, remaining, velocity, workdaysPerPeriod float64) (projected time.Time, ok bool) {
+	if velocity <= 0 {
+		return time.Time{}, false
+	}
+	if remaining <= 0 {
+		return date, true
+	}
+	return addWorkdays(date, int(math.Ceil((remaining/velocity)*workdaysPerPeriod))), true
+}
+
+// PeriodsAfter moves the date forward by a number of periods, counting workdays as the projections do.
+func PeriodsAfter(date time.Time, periods, workdaysPerPeriod float64) time.Time {
+	return addWorkdays(date, int(math.Ceil(periods*workdaysPerPeriod)))
+}
@@ -0,0 +1,215 @@
+package chart
+
+import (
+	"math"
+
+	"go-burndown/burndown"
+	"go-burndown/config"
+)
+
+// The charts, as named in the configured titles and image file names.
+const (
+	ChartBurndown = "burndown"
+	ChartBurnup   = "burnup"
+	ChartVelocity = "velocity"
+	ChartForecast = "forecast"
+)
+
+// The series colors, as named in the configured colors.
+const (
+	ColorRemaining = "remaining"
+	ColorCompleted = "completed"
+	ColorScope     = "scope"
+	ColorVelocity  = "velocity"
+	ColorAverage   = "average"
+	ColorForecast  = "forecast"
+)
+
+const (
+	// Default chart size in pixels.
+	//revive:disable:var-naming
+	_DEFAULT_WIDTH  = 720
+	_DEFAULT_HEIGHT = 360
+	// The furthest the forecast chart looks ahead, in periods.
+	_MAX_FORECAST_PERIODS = 52
+)
+
+// Default titles and colors, matching the Excel charts.
+var (
+	defaultTitles = map[string]string{
+		ChartBurndown: "Burndown",
+		ChartBurnup:   "Burnup",
+		ChartVelocity: "Velocity",
+		ChartForecast: "Forecast",
+	}
+	defaultColors = map[string]string{
+		ColorRemaining: "#4472c4",
+		ColorCompleted: "#70ad47",
+		ColorScope:     "#a5a5a5",
+		ColorVelocity:  "#4472c4",
+		ColorAverage:   "#ed7d31",
+		ColorForecast:  "#ed7d31",
+	}
+)
+
+// Style is the size, titles and colors of the charts.
+type Style struct {
+	Width  int
+	Height int
+	// Titles and Colors override the defaults, keyed by chart and series color names.
+	Titles map[string]string
+	Colors map[string]string
+}
+
+// NewStyle returns the configured chart style.
+func NewStyle(config *config.Config) Style {
+	style := Style{
+		Width:  _DEFAULT_WIDTH,
+		Height: _DEFAULT_HEIGHT,
+		Titles: config.Charts.Titles,
+		Colors: config.Charts.Colors,
+	}
+	if config.Charts.Width > 0 {
+		style.Width = int(config.Charts.Width)
+	}
+	if config.Charts.Height > 0 {
+		style.Height = int(config.Charts.Height)
+	}
+	return style
+}
+
+func (style Style) title(chart string) string {
+	if title, ok := style.Titles[chart]; ok {
+		return title
+	}
+	return defaultTitles[chart]
+}
+
+func (style Style) color(name string) string {
+	if color, ok := style.Colors[name]; ok {
+		return color
+	}
+	return defaultColors[name]
+}
+
+// Burndown charts the work remaining at the end of each period.
+func Burndown(series burndown.Series, style Style) Chart {
+	remaining := make([]float64, len(series.Points))
+	for i, point := range series.Points {
+		remaining[i] = point.Remaining
+	}
+	return style.newChart(ChartBurndown, periodLabels(series.Timeline), []Series{
+		{Name: "Remaining", Kind: Line, Color: style.color(ColorRemaining), Values: remaining},
+	})
+}
+
+// Burnup charts the work completed against the total scope at the end of each period.
+func Burnup(series burndown.Series, style Style) Chart {
+	completed := make([]float64, len(series.Points))
+	scope := make([]float64, len(series.Points))
+	for i, point := range series.Points {
+		completed[i] = point.Completed
+		scope[i] = point.Scope
+	}
+	return style.newChart(ChartBurnup, periodLabels(series.Timeline), []Series{
+		{Name: "Completed", Kind: Line, Color: style.color(ColorCompleted), Values: completed},
+		{Name: "Scope", Kind: Line, Color: style.color(ColorScope), Values: scope},
+	})
+}
+
+// Velocity charts the work completed in each period with its moving average.
+func Velocity(series burndown.Series, style Style) Chart {
+	velocity := make([]float64, len(series.Points))
+	average := make([]float64, len(series.Points))
+	for i, point := range series.Points {
+		velocity[i], average[i] = math.NaN(), math.NaN()
+		if point.HasVelocity {
+			velocity[i] = point.Velocity
+		}
+		if point.HasAvg {
+			average[i] = point.AvgVelocity
+		}
+	}
+	return style.newChart(ChartVelocity, periodLabels(series.Timeline), []Series{
+		{Name: "Velocity", Kind: Bar, Color: style.color(ColorVelocity), Values: velocity},
+		{Name: "Average", Kind: Line, Color: style.color(ColorAverage), Values: average},
+	})
+}
+
+// Forecast charts the work remaining, continued past the latest period as a cone from the slow (p68)
+// to the fast (p68) velocity around the mean, until the slow velocity would finish the work.
+// The cone is left out until there is a forecast.
+func Forecast(series burndown.Series, style Style) Chart {
+	labels := periodLabels(series.Timeline)
+	remaining := make([]float64, len(series.Points))
+	for i, point := range series.Points {
+		remaining[i] = point.Remaining
+	}
+
+	actual := Series{Name: "Remaining", Kind: Line, Color: style.color(ColorRemaining), Values: remaining}
+
+	latestIndex := len(series.Points) - 1
+	if latestIndex < 0 {
+		return style.newChart(ChartForecast, labels, []Series{actual})
+	}
+	latest := series.Points[latestIndex]
+	fast, slow, mean := latest.AvgVelocity+latest.StdDev, latest.AvgVelocity-latest.StdDev, latest.AvgVelocity
+	if _, ok := series.Forecast(latestIndex); !ok || mean <= 0 {
+		return style.newChart(ChartForecast, labels, []Series{actual})
+	}
+
+	// Look ahead until the slowest projection finishes. At no slow velocity, look twice as far as the mean.
+	ahead := latest.Remaining / mean * 2
+	if slow > 0 {
+		ahead = latest.Remaining / slow
+	}
+	ahead = math.Min(math.Ceil(ahead), _MAX_FORECAST_PERIODS)
+
+	// The projections start from the latest period's remaining work, so the cone joins the actual line.
+	meanLine := make([]float64, len(series.Points))
+	upper := make([]float64, len(series.Points))
+	lower := make([]float64, len(series.Points))
+	for i := range series.Points {
+		meanLine[i], upper[i], lower[i] = math.NaN(), math.NaN(), math.NaN()
+	}
+	meanLine[latestIndex], upper[latestIndex], lower[latestIndex] = latest.Remaining, latest.Remaining, latest.Remaining
+	for period := 1.0; period <= ahead; period++ {
+		date := burndown.PeriodsAfter(latest.Period.End, period, series.Timeline.WorkdaysPerPeriod)
+		labels = append(labels, date.Format("2006-01-02"))
+		remaining = append(remaining, math.NaN())
+		meanLine = append(meanLine, math.Max(latest.Remaining-mean*period, 0))
+		// The slow velocity leaves the most work remaining, so it's the top of the cone.
+		upper = append(upper, math.Max(latest.Remaining-slow*period, 0))
+		lower = append(lower, math.Max(latest.Remaining-fast*period, 0))
+	}
+	actual.Values = remaining
+
+	return style.newChart(ChartForecast, labels, []Series{
+		actual,
+		{Name: "Fast-Slow (p68)", Kind: Band, Color: style.color(ColorForecast), Values: upper, Lower: lower},
+		{Name: "Mean", Kind: Line, Color: style.color(ColorForecast), Values: meanLine},
+	})
+}
+
+func (style Style) newChart(chart string, labels []string, series []Series) Chart {
+	return Chart{
+		Title:  style.title(chart),
+		Width:  style.Width,
+		Height: style.Height,
+		Labels: labels,
+		Series: series,
+	}
+}
+
+// periodLabels names each period by its sprint, or by its end date.
+func periodLabels(timeline burndown.Timeline) []string {
+	labels := make([]string, len(timeline.Periods))
+	for i, period := range timeline.Periods {
+		if period.Sprint != nil {
+			labels[i] = period.Sprint.Name
+			continue
+		}
+		labels[i] = period.End.Format("2006-01-02")
+	}
+	return labels
+}
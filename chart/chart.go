@@ -0,0 +1,274 @@
+// Package chart renders burndown charts as SVG and PNG images in pure Go.
+package chart
+
+import (
+	"bytes"
+	"image/png"
+	"math"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// Line draws a series as a line through its points.
+	Line = "line"
+	// Bar draws a series as a bar per category.
+	Bar = "bar"
+	// Band shades the area between a series' values and its lower values.
+	Band = "band"
+)
+
+const (
+	// Space around the plot area for the title, legend and axis labels.
+	//revive:disable:var-naming
+	_MARGIN_TOP    = 52
+	_MARGIN_RIGHT  = 20
+	_MARGIN_BOTTOM = 36
+	_MARGIN_LEFT   = 56
+	// How many horizontal grid lines to aim for.
+	_Y_TICKS = 5
+	// The approximate width of a character, for spacing labels and the legend.
+	_CHAR_WIDTH = 7
+	// How opaque a band is, so lines behind it still show.
+	_BAND_OPACITY = 0.25
+)
+
+// Series is one set of values plotted against the chart's categories. NaN values are gaps.
+type Series struct {
+	Name   string
+	Kind   string
+	Color  string
+	Values []float64
+	// Lower is the bottom edge of a band, parallel to Values.
+	Lower []float64
+}
+
+// Chart is a categorical chart, such as values per reporting period.
+type Chart struct {
+	Title  string
+	Width  int
+	Height int
+	// Labels name each category along the x axis.
+	Labels []string
+	Series []Series
+}
+
+// point is a position on the canvas, in pixels from the top left.
+type point struct {
+	x, y float64
+}
+
+// textStyle is how a string is placed relative to its position.
+type textStyle struct {
+	anchor string // "start", "middle" or "end", as in SVG.
+	bold   bool
+}
+
+// canvas is what a chart is drawn onto, such as an SVG document or a PNG image.
+type canvas interface {
+	rect(x, y, width, height float64, color string, opacity float64)
+	line(from, to point, color string, width float64)
+	polyline(points []point, color string, width float64)
+	polygon(points []point, color string, opacity float64)
+	text(at point, s string, color string, style textStyle)
+}
+
+// plot maps values into the plot area.
+type plot struct {
+	left, top, width, height float64
+	maxValue                 float64
+	categories               int
+}
+
+// SVG renders the chart as a standalone SVG document.
+func (c *Chart) SVG() string {
+	canvas := newSVGCanvas(c.Width, c.Height)
+	c.draw(canvas)
+	return canvas.String()
+}
+
+// PNG renders the chart as a PNG image.
+func (c *Chart) PNG() ([]byte, error) {
+	canvas := newPNGCanvas(c.Width, c.Height)
+	c.draw(canvas)
+
+	var buffer bytes.Buffer
+	if err := png.Encode(&buffer, canvas.image); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return buffer.Bytes(), nil
+}
+
+func (c *Chart) draw(canvas canvas) {
+	p := c.plot()
+
+	canvas.rect(0, 0, float64(c.Width), float64(c.Height), "#ffffff", 1)
+	canvas.text(point{float64(c.Width) / 2, 22}, c.Title, "#202020", textStyle{anchor: "middle", bold: true})
+
+	// Horizontal grid lines with their values.
+	for i := 0; i <= _Y_TICKS; i++ {
+		value := p.maxValue * float64(i) / _Y_TICKS
+		y := p.y(value)
+		canvas.line(point{p.left, y}, point{p.left + p.width, y}, "#e0e0e0", 1)
+		canvas.text(point{p.left - 6, y + 4}, formatTick(value), "#404040", textStyle{anchor: "end"})
+	}
+
+	// Category labels, skipping some so they don't overlap.
+	step := labelStep(c.Labels, p.width)
+	for i, label := range c.Labels {
+		if i%step != 0 {
+			continue
+		}
+		canvas.text(point{p.x(i), p.top + p.height + 16}, label, "#404040", textStyle{anchor: "middle"})
+	}
+
+	// Bands first, then bars, so lines are drawn over them.
+	for _, series := range c.seriesOfKind(Band) {
+		for _, segment := range segments(series.Values) {
+			var points []point
+			for _, i := range segment {
+				points = append(points, point{p.x(i), p.y(series.Values[i])})
+			}
+			for j := len(segment) - 1; j >= 0; j-- {
+				i := segment[j]
+				points = append(points, point{p.x(i), p.y(series.Lower[i])})
+			}
+			canvas.polygon(points, series.Color, _BAND_OPACITY)
+		}
+	}
+	bars := c.seriesOfKind(Bar)
+	for barIndex, series := range bars {
+		bandWidth := p.width / float64(max(p.categories, 1))
+		barWidth := bandWidth * 0.7 / float64(len(bars))
+		for i, value := range series.Values {
+			if math.IsNaN(value) {
+				continue
+			}
+			x := p.x(i) - bandWidth*0.35 + float64(barIndex)*barWidth
+			top := math.Min(p.y(value), p.y(0))
+			height := math.Abs(p.y(0) - p.y(value))
+			canvas.rect(x, top, barWidth, height, series.Color, 1)
+		}
+	}
+	for _, series := range c.seriesOfKind(Line) {
+		for _, segment := range segments(series.Values) {
+			var points []point
+			for _, i := range segment {
+				points = append(points, point{p.x(i), p.y(series.Values[i])})
+			}
+			if len(points) == 1 {
+				canvas.rect(points[0].x-2, points[0].y-2, 4, 4, series.Color, 1)
+				continue
+			}
+			canvas.polyline(points, series.Color, 2)
+		}
+	}
+
+	// Axes.
+	canvas.line(point{p.left, p.top}, point{p.left, p.top + p.height}, "#606060", 1)
+	canvas.line(point{p.left, p.y(0)}, point{p.left + p.width, p.y(0)}, "#606060", 1)
+
+	// Legend along the top right.
+	x := float64(c.Width - _MARGIN_RIGHT)
+	for i := len(c.Series) - 1; i >= 0; i-- {
+		series := c.Series[i]
+		x -= float64(len(series.Name)*_CHAR_WIDTH) + 24
+		opacity := 1.0
+		if series.Kind == Band {
+			opacity = _BAND_OPACITY
+		}
+		canvas.rect(x, 30, 10, 10, series.Color, opacity)
+		canvas.text(point{x + 14, 39}, series.Name, "#202020", textStyle{anchor: "start"})
+	}
+}
+
+func (c *Chart) plot() plot {
+	p := plot{
+		left:       _MARGIN_LEFT,
+		top:        _MARGIN_TOP,
+		width:      float64(c.Width - _MARGIN_LEFT - _MARGIN_RIGHT),
+		height:     float64(c.Height - _MARGIN_TOP - _MARGIN_BOTTOM),
+		categories: len(c.Labels),
+	}
+	for _, series := range c.Series {
+		for _, value := range series.Values {
+			if !math.IsNaN(value) {
+				p.maxValue = math.Max(p.maxValue, value)
+			}
+		}
+	}
+	p.maxValue = niceCeiling(p.maxValue)
+	return p
+}
+
+// x is the center of a category.
+func (p plot) x(category int) float64 {
+	bandWidth := p.width / float64(max(p.categories, 1))
+	return p.left + bandWidth*(float64(category)+0.5)
+}
+
+// y is the height of a value, with negative values clamped to the axis.
+func (p plot) y(value float64) float64 {
+	value = math.Max(value, 0)
+	return p.top + p.height - (value/p.maxValue)*p.height
+}
+
+func (c *Chart) seriesOfKind(kind string) (series []Series) {
+	for _, s := range c.Series {
+		if s.Kind == kind {
+			series = append(series, s)
+		}
+	}
+	return series
+}
+
+// segments splits a series' indexes into runs without gaps.
+func segments(values []float64) (runs [][]int) {
+	var run []int
+	for i, value := range values {
+		if math.IsNaN(value) {
+			if len(run) > 0 {
+				runs = append(runs, run)
+			}
+			run = nil
+			continue
+		}
+		run = append(run, i)
+	}
+	if len(run) > 0 {
+		runs = append(runs, run)
+	}
+	return runs
+}
+
+// niceCeiling rounds the maximum up to a value that divides into readable ticks.
+func niceCeiling(value float64) float64 {
+	if value <= 0 {
+		return 1
+	}
+	magnitude := math.Pow(10, math.Floor(math.Log10(value)))
+	for _, multiple := range []float64{1, 2, 2.5, 5, 10} {
+		if multiple*magnitude >= value {
+			return multiple * magnitude
+		}
+	}
+	return 10 * magnitude
+}
+
+// labelStep is how many categories apart to label so the widest label fits with a gap either side.
+func labelStep(labels []string, width float64) int {
+	widest := 0
+	for _, label := range labels {
+		widest = max(widest, len(label))
+	}
+	fits := int(width / float64((widest+2)*_CHAR_WIDTH))
+	if fits < 1 || len(labels) == 0 {
+		return max(1, len(labels))
+	}
+	return max(1, int(math.Ceil(float64(len(labels))/float64(fits))))
+}
+
+func formatTick(value float64) string {
+	return strconv.FormatFloat(math.Round(value*10)/10, 'f', -1, 64)
+}
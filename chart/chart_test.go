@@ -0,0 +1,68 @@
+package chart
+
+import (
+	"image/color"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNiceCeiling(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   float64
+		ceiling float64
+	}{
+		{name: "empty chart", value: 0, ceiling: 1},
+		{name: "already nice", value: 20, ceiling: 20},
+		{name: "rounds up to two and a half", value: 23, ceiling: 25},
+		{name: "rounds up to five", value: 33, ceiling: 50},
+		{name: "rounds up to the next magnitude", value: 73, ceiling: 100},
+		{name: "fractions", value: 0.3, ceiling: 0.5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.ceiling, niceCeiling(tt.value), 1e-9)
+		})
+	}
+}
+
+func TestSegments(t *testing.T) {
+	nan := math.NaN()
+	tests := []struct {
+		name   string
+		values []float64
+		runs   [][]int
+	}{
+		{name: "no values", values: nil, runs: nil},
+		{name: "no gaps", values: []float64{1, 2, 3}, runs: [][]int{{0, 1, 2}}},
+		{name: "leading gap", values: []float64{nan, 2, 3}, runs: [][]int{{1, 2}}},
+		{name: "gap in the middle", values: []float64{1, nan, 3, 4}, runs: [][]int{{0}, {2, 3}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.runs, segments(tt.values))
+		})
+	}
+}
+
+func TestParseColor(t *testing.T) {
+	tests := []struct {
+		name  string
+		hex   string
+		color color.RGBA
+	}{
+		{name: "six digits", hex: "#4472c4", color: color.RGBA{R: 0x44, G: 0x72, B: 0xc4, A: 255}},
+		{name: "three digits", hex: "#f80", color: color.RGBA{R: 0xff, G: 0x88, B: 0x00, A: 255}},
+		{name: "not hex is black", hex: "red", color: color.RGBA{A: 255}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.color, parseColor(tt.hex))
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package chart
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	"go-burndown/burndown"
+	"go-burndown/config"
+	"go-burndown/jira"
+)
+
+// GenerateChartImages writes the burndown, burnup, velocity and forecast charts to the configured directory
+// in each configured format, e.g. "burndown.svg" and "burndown.png", from the same series as the Projections sheet.
+func GenerateChartImages(config *config.Config, issues []jira.Issue, timeline burndown.Timeline) (files []string, err error) {
+	series, err := burndown.NewSeries(config, issues, timeline)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	if err := os.MkdirAll(config.Charts.OutputDir, 0o755); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	style := NewStyle(config)
+	charts := []struct {
+		name  string
+		chart Chart
+	}{
+		{ChartBurndown, Burndown(series, style)},
+		{ChartBurnup, Burnup(series, style)},
+		{ChartVelocity, Velocity(series, style)},
+		{ChartForecast, Forecast(series, style)},
+	}
+
+	for _, c := range charts {
+		for _, format := range config.Charts.FormatsOrDefault() {
+			var data []byte
+			switch format {
+			case "png":
+				if data, err = c.chart.PNG(); err != nil {
+					return nil, errors.WithStack(err)
+				}
+			default:
+				data = []byte(c.chart.SVG())
+			}
+
+			file := filepath.Join(config.Charts.OutputDir, c.name+"."+format)
+			if err := os.WriteFile(file, data, 0o644); err != nil {
+				return nil, errors.WithStack(err)
+			}
+			files = append(files, file)
+		}
+	}
+
+	return files, nil
+}
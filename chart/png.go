@@ -0,0 +1,137 @@
+package chart
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"sort"
+	"strconv"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+)
+
+// pngCanvas rasterizes a chart onto an image, with a built-in bitmap font so no fonts need installing.
+type pngCanvas struct {
+	image *image.RGBA
+}
+
+func newPNGCanvas(width, height int) *pngCanvas {
+	return &pngCanvas{image: image.NewRGBA(image.Rect(0, 0, width, height))}
+}
+
+func (c *pngCanvas) rect(x, y, width, height float64, color string, opacity float64) {
+	fill := parseColor(color)
+	for py := int(math.Round(y)); py < int(math.Round(y+height)); py++ {
+		for px := int(math.Round(x)); px < int(math.Round(x+width)); px++ {
+			c.blend(px, py, fill, opacity)
+		}
+	}
+}
+
+func (c *pngCanvas) line(from, to point, color string, width float64) {
+	stroke := parseColor(color)
+	length := math.Hypot(to.x-from.x, to.y-from.y)
+	steps := max(1, int(math.Ceil(length*2)))
+	radius := width / 2
+	// Stamp a square of the line's width at every half pixel along it.
+	for i := 0; i <= steps; i++ {
+		t := float64(i) / float64(steps)
+		x := from.x + (to.x-from.x)*t
+		y := from.y + (to.y-from.y)*t
+		for py := int(math.Floor(y - radius + 0.5)); py < int(math.Floor(y+radius+0.5)); py++ {
+			for px := int(math.Floor(x - radius + 0.5)); px < int(math.Floor(x+radius+0.5)); px++ {
+				c.set(px, py, stroke)
+			}
+		}
+	}
+}
+
+func (c *pngCanvas) polyline(points []point, color string, width float64) {
+	for i := 1; i < len(points); i++ {
+		c.line(points[i-1], points[i], color, width)
+	}
+}
+
+// polygon fills the polygon a row at a time, between pairs of edge crossings.
+func (c *pngCanvas) polygon(points []point, color string, opacity float64) {
+	fill := parseColor(color)
+	bounds := c.image.Bounds()
+	for py := bounds.Min.Y; py < bounds.Max.Y; py++ {
+		y := float64(py) + 0.5
+		var crossings []float64
+		for i := range points {
+			a, b := points[i], points[(i+1)%len(points)]
+			if (a.y <= y) == (b.y <= y) {
+				continue
+			}
+			crossings = append(crossings, a.x+(y-a.y)/(b.y-a.y)*(b.x-a.x))
+		}
+		sort.Float64s(crossings)
+		for i := 0; i+1 < len(crossings); i += 2 {
+			for px := int(math.Round(crossings[i])); px < int(math.Round(crossings[i+1])); px++ {
+				c.blend(px, py, fill, opacity)
+			}
+		}
+	}
+}
+
+func (c *pngCanvas) text(at point, s string, textColor string, style textStyle) {
+	drawer := font.Drawer{
+		Dst:  c.image,
+		Src:  image.NewUniform(parseColor(textColor)),
+		Face: basicfont.Face7x13,
+	}
+	x := at.x
+	switch style.anchor {
+	case "middle":
+		x -= float64(drawer.MeasureString(s).Round()) / 2
+	case "end":
+		x -= float64(drawer.MeasureString(s).Round())
+	}
+	drawer.Dot = fixed.P(int(math.Round(x)), int(math.Round(at.y)))
+	drawer.DrawString(s)
+	if style.bold {
+		// The bitmap font has no bold face, so overstrike it one pixel to the right.
+		drawer.Dot = fixed.P(int(math.Round(x))+1, int(math.Round(at.y)))
+		drawer.DrawString(s)
+	}
+}
+
+func (c *pngCanvas) set(x, y int, fill color.RGBA) {
+	if image.Pt(x, y).In(c.image.Bounds()) {
+		c.image.SetRGBA(x, y, fill)
+	}
+}
+
+// blend paints a partially transparent color over a pixel.
+func (c *pngCanvas) blend(x, y int, fill color.RGBA, opacity float64) {
+	if !image.Pt(x, y).In(c.image.Bounds()) {
+		return
+	}
+	if opacity >= 1 {
+		c.image.SetRGBA(x, y, fill)
+		return
+	}
+	under := c.image.RGBAAt(x, y)
+	mix := func(over, under uint8) uint8 {
+		return uint8(math.Round(float64(over)*opacity + float64(under)*(1-opacity)))
+	}
+	c.image.SetRGBA(x, y, color.RGBA{R: mix(fill.R, under.R), G: mix(fill.G, under.G), B: mix(fill.B, under.B), A: 255})
+}
+
+// parseColor parses a "#rrggbb" or "#rgb" color, drawing anything else in black.
+func parseColor(hex string) color.RGBA {
+	if len(hex) == 4 && hex[0] == '#' {
+		hex = "#" + string([]byte{hex[1], hex[1], hex[2], hex[2], hex[3], hex[3]})
+	}
+	if len(hex) != 7 || hex[0] != '#' {
+		return color.RGBA{A: 255}
+	}
+	value, err := strconv.ParseUint(hex[1:], 16, 32)
+	if err != nil {
+		return color.RGBA{A: 255}
+	}
+	return color.RGBA{R: uint8(value >> 16), G: uint8(value >> 8), B: uint8(value), A: 255}
+}
@@ -0,0 +1,73 @@
+package chart
+
+import (
+	"fmt"
+	"html"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// svgCanvas draws a chart as SVG elements.
+type svgCanvas struct {
+	builder strings.Builder
+}
+
+func newSVGCanvas(width, height int) *svgCanvas {
+	c := &svgCanvas{}
+	fmt.Fprintf(&c.builder, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif" font-size="11">`, width, height, width, height)
+	return c
+}
+
+// String closes the document and returns it.
+func (c *svgCanvas) String() string {
+	return c.builder.String() + `</svg>`
+}
+
+func (c *svgCanvas) rect(x, y, width, height float64, color string, opacity float64) {
+	fmt.Fprintf(&c.builder, `<rect x="%s" y="%s" width="%s" height="%s" fill="%s"%s/>`, num(x), num(y), num(width), num(height), attr(color), fillOpacity(opacity))
+}
+
+func (c *svgCanvas) line(from, to point, color string, width float64) {
+	fmt.Fprintf(&c.builder, `<line x1="%s" y1="%s" x2="%s" y2="%s" stroke="%s" stroke-width="%s"/>`, num(from.x), num(from.y), num(to.x), num(to.y), attr(color), num(width))
+}
+
+func (c *svgCanvas) polyline(points []point, color string, width float64) {
+	fmt.Fprintf(&c.builder, `<polyline points="%s" fill="none" stroke="%s" stroke-width="%s" stroke-linejoin="round"/>`, svgPoints(points), attr(color), num(width))
+}
+
+func (c *svgCanvas) polygon(points []point, color string, opacity float64) {
+	fmt.Fprintf(&c.builder, `<polygon points="%s" fill="%s"%s/>`, svgPoints(points), attr(color), fillOpacity(opacity))
+}
+
+func (c *svgCanvas) text(at point, s string, color string, style textStyle) {
+	weight := ""
+	if style.bold {
+		weight = ` font-size="15" font-weight="bold"`
+	}
+	fmt.Fprintf(&c.builder, `<text x="%s" y="%s" text-anchor="%s" fill="%s"%s>%s</text>`, num(at.x), num(at.y), style.anchor, attr(color), weight, html.EscapeString(s))
+}
+
+func svgPoints(points []point) string {
+	coordinates := make([]string, len(points))
+	for i, p := range points {
+		coordinates[i] = num(p.x) + "," + num(p.y)
+	}
+	return strings.Join(coordinates, " ")
+}
+
+func fillOpacity(opacity float64) string {
+	if opacity >= 1 {
+		return ""
+	}
+	return ` fill-opacity="` + num(opacity) + `"`
+}
+
+func attr(value string) string {
+	return html.EscapeString(value)
+}
+
+// num formats a coordinate compactly.
+func num(value float64) string {
+	return strconv.FormatFloat(math.Round(value*100)/100, 'f', -1, 64)
+}
@@ -6,19 +6,41 @@ import (
 	"flag"
 	"fmt"
 	"log"
+	"strings"
+	"time"
 
+	"go-burndown/burndown"
+	"go-burndown/chart"
 	"go-burndown/config"
+	"go-burndown/csvreport"
 	"go-burndown/excel"
+	"go-burndown/htmlreport"
 	"go-burndown/jira"
+	"go-burndown/jsonreport"
+	"go-burndown/mdreport"
 
 	"github.com/pkg/errors"
 )
 
+// The output formats, named here since the loaded config shadows its package in main.
+const (
+	formatCSV      = config.FormatCSV
+	formatJSON     = config.FormatJSON
+	formatHTML     = config.FormatHTML
+	formatMarkdown = config.FormatMarkdown
+)
+
 func main() {
 	configFile := flag.String("config", "", "Path to configuration file")
 	jql := flag.String("jql", "", "JQL query")
-	outputFile := flag.String("output", "", "Output Excel file")
+	outputFile := flag.String("output", "", "Output file")
+	outputFormat := flag.String("format", "", "Output format: xlsx, csv, json, html or md (default from the output file extension)")
 	startDate := flag.String("start-date", "", "Project start date (YYYY-MM-DD)")
+	backtest := flag.Bool("backtest", false, "Add forecast backtest sheets")
+	chartsDir := flag.String("charts", "", "Directory to write chart images to")
+	formulas := flag.String("formulas", "", "How Excel formulas are written: live, cached or values (default live)")
+	template := flag.String("template", "", "Excel template to start the workbook from")
+	update := flag.Bool("update", false, "Update the existing workbook, keeping sheets and notes added to it")
 	flag.Parse()
 
 	// Set defaults if flags are empty
@@ -39,9 +61,27 @@ func main() {
 	if *outputFile != "" {
 		config.OutputFile = *outputFile
 	}
+	if *outputFormat != "" {
+		config.OutputFormat = *outputFormat
+	}
 	if *startDate != "" {
 		config.StartDate = *startDate
 	}
+	if *backtest {
+		config.Backtest.Enabled = true
+	}
+	if *chartsDir != "" {
+		config.Charts.OutputDir = *chartsDir
+	}
+	if *formulas != "" {
+		config.Formulas = *formulas
+	}
+	if *template != "" {
+		config.Template = *template
+	}
+	if *update {
+		config.Update = true
+	}
 
 	// Validate configuration
 	if err := config.Validate(); err != nil {
@@ -58,12 +98,75 @@ func main() {
 		log.Fatalf("Jira query error: %+v", wrappedErr)
 	}
 
-	// Generate Excel report
-	err = excel.GenerateExcelReport(&config, issues)
-	if err != nil {
-		wrappedErr := errors.Wrap(err, "failed to generate Excel report")
-		log.Fatalf("Excel generation error: %+v", wrappedErr)
+	// Bucket progress into reporting periods
+	var timeline burndown.Timeline
+	if config.UsesSprints() {
+		sprints, err := jira.QuerySprints(ctx, &config)
+		if err != nil {
+			wrappedErr := errors.Wrap(err, "failed to query Jira sprints")
+			log.Fatalf("Jira sprint query error: %+v", wrappedErr)
+		}
+		timeline, err = burndown.NewSprintTimeline(&config, sprints, time.Now())
+		if err != nil {
+			log.Fatalf("Reporting period error: %+v", err)
+		}
+	} else {
+		timeline, err = burndown.NewCalendarTimeline(&config, time.Now())
+		if err != nil {
+			log.Fatalf("Reporting period error: %+v", err)
+		}
+	}
+
+	// Generate the report in the requested format
+	switch config.OutputFormatOrDefault() {
+	case formatCSV:
+		files, err := csvreport.GenerateCSVReport(&config, issues, timeline)
+		if err != nil {
+			wrappedErr := errors.Wrap(err, "failed to generate CSV report")
+			log.Fatalf("CSV generation error: %+v", wrappedErr)
+		}
+		fmt.Printf("Burndown report generated: %s\n", strings.Join(files, ", "))
+
+	case formatJSON:
+		err = jsonreport.GenerateJSONReport(&config, issues, timeline)
+		if err != nil {
+			wrappedErr := errors.Wrap(err, "failed to generate JSON report")
+			log.Fatalf("JSON generation error: %+v", wrappedErr)
+		}
+		fmt.Printf("Burndown report generated: %s\n", config.OutputFile)
+
+	case formatHTML:
+		err = htmlreport.GenerateHTMLReport(&config, issues, timeline)
+		if err != nil {
+			wrappedErr := errors.Wrap(err, "failed to generate HTML report")
+			log.Fatalf("HTML generation error: %+v", wrappedErr)
+		}
+		fmt.Printf("Burndown report generated: %s\n", config.OutputFile)
+
+	case formatMarkdown:
+		err = mdreport.GenerateMarkdownReport(&config, issues, timeline)
+		if err != nil {
+			wrappedErr := errors.Wrap(err, "failed to generate Markdown report")
+			log.Fatalf("Markdown generation error: %+v", wrappedErr)
+		}
+		fmt.Printf("Burndown report generated: %s\n", config.OutputFile)
+
+	default:
+		err = excel.GenerateExcelReport(&config, issues, timeline)
+		if err != nil {
+			wrappedErr := errors.Wrap(err, "failed to generate Excel report")
+			log.Fatalf("Excel generation error: %+v", wrappedErr)
+		}
+		fmt.Printf("Burndown report generated: %s\n", config.OutputFile)
 	}
 
-	fmt.Printf("Burndown report generated: %s\n", config.OutputFile)
+	// Export the chart images if requested
+	if config.Charts.OutputDir != "" {
+		files, err := chart.GenerateChartImages(&config, issues, timeline)
+		if err != nil {
+			wrappedErr := errors.Wrap(err, "failed to generate chart images")
+			log.Fatalf("Chart generation error: %+v", wrappedErr)
+		}
+		fmt.Printf("Chart images generated: %s\n", strings.Join(files, ", "))
+	}
 }
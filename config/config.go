@@ -4,29 +4,195 @@ package config
 import (
 	"encoding/json"
 	"os"
+	"path/filepath"
 	"slices"
+	"strings"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/pkg/errors"
 )
 
+const (
+	// SizingPoints sizes each issue by the value in the configured size field.
+	SizingPoints = "points"
+	// SizingCount sizes each issue as one unit (optionally weighted by issue type).
+	SizingCount = "count"
+)
+
+const (
+	// FormatXLSX writes an Excel workbook (the default).
+	FormatXLSX = "xlsx"
+	// FormatCSV writes one CSV file per table.
+	FormatCSV = "csv"
+	// FormatJSON writes a JSON document with a versioned schema.
+	FormatJSON = "json"
+	// FormatHTML writes a single self-contained HTML page.
+	FormatHTML = "html"
+	// FormatMarkdown writes a Markdown status summary of the latest period.
+	FormatMarkdown = "md"
+)
+
+const (
+	// FormulasLive writes formulas for the spreadsheet app to compute when the workbook is opened (the default).
+	FormulasLive = "live"
+	// FormulasCached writes formulas along with their computed values, so viewers that don't compute formulas show the values.
+	FormulasCached = "cached"
+	// FormulasValues writes only the computed values, for apps that compute formulas differently or not at all.
+	FormulasValues = "values"
+)
+
+const (
+	// ChartFormatSVG writes chart images as SVG.
+	ChartFormatSVG = "svg"
+	// ChartFormatPNG writes chart images as PNG.
+	ChartFormatPNG = "png"
+)
+
+const (
+	// FlowMeasureCount counts the issues in each status of the cumulative flow (the default).
+	FlowMeasureCount = "count"
+	// FlowMeasureSize totals the size of the issues in each status of the cumulative flow.
+	FlowMeasureSize = "size"
+)
+
+const (
+	// BreakdownAssignee groups issues by their assignee at the time the work was done.
+	BreakdownAssignee = "assignee"
+	// BreakdownTeam groups issues by the team custom field at the time the work was done.
+	BreakdownTeam = "team"
+	// BreakdownComponent groups issues by their components.
+	BreakdownComponent = "component"
+)
+
+const (
+	// GroupByLabels splits issues into a burndown per label.
+	GroupByLabels = "labels"
+	// GroupByComponents splits issues into a burndown per component.
+	GroupByComponents = "components"
+	// GroupByEpic splits issues into a burndown per epic (their parent issue).
+	GroupByEpic = "epic"
+	// GroupByFixVersions splits issues into a burndown per fix version.
+	GroupByFixVersions = "fixVersions"
+)
+
+const (
+	// ColumnEpic shows an issue's epic (its parent issue, or its parent's parent for a subtask) in a Work sheet column.
+	ColumnEpic = "epic"
+	// ColumnSprint shows an issue's sprints, from the sprint field, in a Work sheet column.
+	ColumnSprint = "sprint"
+)
+
+// columnHeaders are the default headers of Work sheet columns for common fields. Other fields default to their ID.
+var columnHeaders = map[string]string{
+	"labels":      "Labels",
+	"components":  "Components",
+	"priority":    "Priority",
+	"fixVersions": "Fix Versions",
+	ColumnSprint:  "Sprint",
+	ColumnEpic:    "Epic",
+	"duedate":     "Due Date",
+	"reporter":    "Reporter",
+}
+
+const (
+	// HighlightUnestimated highlights open issues with no size on the Work sheet.
+	HighlightUnestimated = "unestimated"
+	// HighlightStalled highlights issues in progress on the Work sheet whose percent complete hasn't changed for a number of periods.
+	HighlightStalled = "stalled"
+	// HighlightRegressed highlights issues on the Work sheet that were reopened or had their percent complete lowered.
+	HighlightRegressed = "regressed"
+	// HighlightSlipping highlights forecast dates on the Projections sheet past the target date.
+	HighlightSlipping = "slipping"
+)
+
+const (
+	// PeriodDaily reports progress every workday.
+	PeriodDaily = "daily"
+	// PeriodWeekly reports progress every 7 days (the default).
+	PeriodWeekly = "weekly"
+	// PeriodBiweekly reports progress every 14 days.
+	PeriodBiweekly = "biweekly"
+	// PeriodMonthly reports progress on the same day each month.
+	PeriodMonthly = "monthly"
+	// PeriodSprint reports progress at the end of each sprint of the configured board.
+	PeriodSprint = "sprint"
+)
+
 // Config holds configuration whats in the burndown and how it generates.
 type Config struct {
-	OutputFile     string     `json:"output_file" validate:"required"`
-	StartDate      string     `json:"start_date" validate:"required,datetime=2006-01-02"`
-	JQL            string     `json:"jql" validate:"required"`
-	MovingAvgWeeks uint       `json:"moving_avg_weeks" validate:"required"`
-	Jira           JiraConfig `json:"jira" validate:"required"`
+	OutputFile     string           `json:"output_file" validate:"required"`
+	OutputFormat   string           `json:"output_format" validate:"omitempty,oneof=xlsx csv json html md"`
+	Template       string           `json:"template" validate:"omitempty,file"` // An Excel workbook to start from, keeping its sheets, names and styles.
+	Formulas       string           `json:"formulas" validate:"omitempty,oneof=live cached values"`
+	Update         bool             `json:"update"` // Update an existing workbook, keeping sheets and Work sheet notes added to it.
+	StartDate      string           `json:"start_date" validate:"required,datetime=2006-01-02"`
+	JQL            string           `json:"jql" validate:"required"`
+	Period         string           `json:"period" validate:"omitempty,oneof=daily weekly biweekly monthly sprint"`
+	PeriodAnchor   string           `json:"period_anchor" validate:"omitempty,oneof=monday tuesday wednesday thursday friday saturday sunday"`
+	MovingAvgWeeks uint             `json:"moving_avg_weeks" validate:"required"` // Counted in periods; named for when periods were always weeks.
+	TargetDates    []string         `json:"target_dates" validate:"omitempty,dive,datetime=2006-01-02"`
+	Backtest       BacktestConfig   `json:"backtest"`
+	Charts         ChartsConfig     `json:"charts"`
+	CumulativeFlow FlowConfig       `json:"cumulative_flow"`
+	Breakdowns     []string         `json:"breakdowns" validate:"omitempty,dive,oneof=assignee team component"`
+	GroupBy        string           `json:"group_by" validate:"omitempty,oneof=labels components epic fixVersions|startswith=customfield_"`
+	Columns        []ColumnConfig   `json:"columns" validate:"omitempty,dive"`
+	StaleDays      uint             `json:"stale_days"` // Open issues not updated for this many days are stale. Defaults to 14.
+	Highlights     HighlightsConfig `json:"highlights"`
+	Jira           JiraConfig       `json:"jira" validate:"required"`
+}
+
+// BacktestConfig holds settings for replaying past forecasts to measure their accuracy.
+type BacktestConfig struct {
+	Enabled          bool   `json:"enabled"`
+	Windows          []uint `json:"windows" validate:"omitempty,dive,gt=0"`
+	MonteCarloTrials uint   `json:"monte_carlo_trials"`
+}
+
+// ChartsConfig holds settings for exporting chart images alongside the report.
+type ChartsConfig struct {
+	OutputDir string            `json:"output_dir"` // Images are only written if set.
+	Formats   []string          `json:"formats" validate:"omitempty,dive,oneof=svg png"`
+	Width     uint              `json:"width" validate:"omitempty,min=200"`
+	Height    uint              `json:"height" validate:"omitempty,min=150"`
+	Titles    map[string]string `json:"titles" validate:"omitempty,dive,keys,oneof=burndown burnup velocity forecast,endkeys"`
+	Colors    map[string]string `json:"colors" validate:"omitempty,dive,keys,oneof=remaining completed scope velocity average forecast,endkeys,hexcolor"`
+}
+
+// FlowConfig holds settings for the cumulative flow of issues through the workflow statuses.
+type FlowConfig struct {
+	Statuses []string `json:"statuses"` // Workflow order, first to last. Statuses not listed follow them.
+	Measure  string   `json:"measure" validate:"omitempty,oneof=count size"`
+}
+
+// ColumnConfig holds an extra Work sheet column showing an issue field.
+type ColumnConfig struct {
+	Field  string `json:"field" validate:"required"` // A Jira field ID, such as priority or customfield_10020, or epic or sprint.
+	Header string `json:"header"`
+}
+
+// HighlightsConfig holds settings for the conditional formatting that draws attention to issues and forecasts.
+type HighlightsConfig struct {
+	Rules          []string          `json:"rules" validate:"omitempty,dive,oneof=unestimated stalled regressed slipping"` // Defaults to all of them.
+	StalledPeriods uint              `json:"stalled_periods"`                                                              // Defaults to 3.
+	Target         string            `json:"target" validate:"omitempty,datetime=2006-01-02"`                              // Defaults to the earliest target date.
+	Colors         map[string]string `json:"colors" validate:"omitempty,dive,keys,oneof=unestimated stalled regressed slipping,endkeys,hexcolor"`
 }
 
 // JiraConfig holds Jira-specific configuration settings.
 type JiraConfig struct {
-	JiraURL              string   `json:"jira_url" validate:"required,url"`
-	Username             string   `json:"username" validate:"required"`
-	APIToken             string   `json:"api_token" validate:"required"`
-	SizeField            string   `json:"size_field" validate:"required"`
-	PercentCompleteField string   `json:"percent_complete_field" validate:"required"`
-	DoneStatuses         []string `json:"done_statuses" validate:"required,min=1"`
+	JiraURL              string             `json:"jira_url" validate:"required,url"`
+	Username             string             `json:"username" validate:"required"`
+	APIToken             string             `json:"api_token" validate:"required"`
+	SizeField            string             `json:"size_field" validate:"required_unless=SizingMode count"`
+	SizingMode           string             `json:"sizing_mode" validate:"omitempty,oneof=points count"`
+	TypeWeights          map[string]float64 `json:"type_weights" validate:"omitempty,dive,gte=0"`
+	PercentCompleteField string             `json:"percent_complete_field" validate:"required"`
+	DoneStatuses         []string           `json:"done_statuses" validate:"required,min=1"`
+	RemovedStatuses      []string           `json:"removed_statuses"` // Issues in these statuses no longer count toward scope.
+	BoardID              int                `json:"board_id" validate:"omitempty,gt=0"`
+	SprintField          string             `json:"sprint_field"`
+	TeamField            string             `json:"team_field"`
 }
 
 // LoadConfig loads configuration from a JSON file.
@@ -54,6 +220,27 @@ func (c *Config) Validate() error {
 	if err != nil {
 		return errors.WithStack(err)
 	}
+
+	// Only weekly and biweekly periods fall on a weekday.
+	if c.PeriodAnchor != "" && c.PeriodOrDefault() != PeriodWeekly && c.PeriodOrDefault() != PeriodBiweekly {
+		return errors.New("invalid configuration: period_anchor only applies to weekly and biweekly periods")
+	}
+
+	// Sprint periods come from a board, which lives in the Jira settings.
+	if c.UsesSprints() && c.Jira.BoardID == 0 {
+		return errors.New("missing required configuration: board_id is required when period is sprint")
+	}
+
+	// A team breakdown needs to know which custom field holds the team.
+	if slices.Contains(c.Breakdowns, BreakdownTeam) && c.Jira.TeamField == "" {
+		return errors.New("missing required configuration: team_field is required for a team breakdown")
+	}
+
+	// Sprint columns read the sprint field.
+	if slices.ContainsFunc(c.Columns, func(column ColumnConfig) bool { return column.Field == ColumnSprint }) && c.Jira.SprintField == "" {
+		return errors.New("missing required configuration: sprint_field is required for a sprint column")
+	}
+
 	return nil
 }
 
@@ -68,3 +255,129 @@ func (c *Config) TicketUrl(ticketId string) (url string) {
 func (c *Config) IsDoneStatus(status string) bool {
 	return slices.Contains(c.Jira.DoneStatuses, status)
 }
+
+// IsRemovedStatus checks if the given status takes an issue out of scope, such as "Won't Do".
+func (c *Config) IsRemovedStatus(status string) bool {
+	return slices.Contains(c.Jira.RemovedStatuses, status)
+}
+
+// OutputFormatOrDefault returns the configured output format, falling back to the output file's extension and then to Excel.
+func (c *Config) OutputFormatOrDefault() string {
+	if c.OutputFormat != "" {
+		return c.OutputFormat
+	}
+	extension := strings.ToLower(strings.TrimPrefix(filepath.Ext(c.OutputFile), "."))
+	if slices.Contains([]string{FormatXLSX, FormatCSV, FormatJSON, FormatHTML, FormatMarkdown}, extension) {
+		return extension
+	}
+	return FormatXLSX
+}
+
+// FormulasOrDefault returns how Excel formulas are written, defaulting to live formulas.
+func (c *Config) FormulasOrDefault() string {
+	if c.Formulas == "" {
+		return FormulasLive
+	}
+	return c.Formulas
+}
+
+// WritesFormulas checks if Excel cells that compute their value are written as formulas.
+func (c *Config) WritesFormulas() bool {
+	return c.FormulasOrDefault() != FormulasValues
+}
+
+// CachesValues checks if Excel cells that compute their value are written with the value, for viewers that don't compute formulas.
+func (c *Config) CachesValues() bool {
+	return c.FormulasOrDefault() != FormulasLive
+}
+
+// FormatsOrDefault returns the configured chart image formats, defaulting to both SVG and PNG.
+func (c *ChartsConfig) FormatsOrDefault() []string {
+	if len(c.Formats) == 0 {
+		return []string{ChartFormatSVG, ChartFormatPNG}
+	}
+	return c.Formats
+}
+
+// IsSizeMeasure checks if the cumulative flow totals issue sizes rather than counting issues.
+func (c *FlowConfig) IsSizeMeasure() bool {
+	return c.Measure == FlowMeasureSize
+}
+
+// HeaderOrDefault returns the column's configured header, defaulting to a name for common fields and otherwise the field ID.
+func (c *ColumnConfig) HeaderOrDefault() string {
+	if c.Header != "" {
+		return c.Header
+	}
+	if header, ok := columnHeaders[c.Field]; ok {
+		return header
+	}
+	return c.Field
+}
+
+// ColumnFieldID returns the Jira field ID a column shows: the parent issue for the epic and the sprint field for sprints.
+func (c *Config) ColumnFieldID(column ColumnConfig) string {
+	switch column.Field {
+	case ColumnEpic:
+		return "parent"
+	case ColumnSprint:
+		return c.Jira.SprintField
+	}
+	return column.Field
+}
+
+// PeriodOrDefault returns the configured reporting period, defaulting to weekly.
+func (c *Config) PeriodOrDefault() string {
+	if c.Period == "" {
+		return PeriodWeekly
+	}
+	return c.Period
+}
+
+// UsesSprints checks if progress is bucketed by the sprints of a board rather than by calendar periods.
+func (c *Config) UsesSprints() bool {
+	return c.PeriodOrDefault() == PeriodSprint
+}
+
+// StaleDaysOrDefault returns the number of days without an update after which an open issue is stale, defaulting to 14.
+func (c *Config) StaleDaysOrDefault() uint {
+	if c.StaleDays == 0 {
+		return 14
+	}
+	return c.StaleDays
+}
+
+// Highlights checks if the highlight rule is on. All rules are on unless some are configured.
+func (c *HighlightsConfig) Highlights(rule string) bool {
+	return len(c.Rules) == 0 || slices.Contains(c.Rules, rule)
+}
+
+// StalledPeriodsOrDefault returns the number of periods without progress after which an issue in progress is stalled, defaulting to 3.
+func (c *HighlightsConfig) StalledPeriodsOrDefault() uint {
+	if c.StalledPeriods == 0 {
+		return 3
+	}
+	return c.StalledPeriods
+}
+
+// HighlightTarget returns the date that forecasts are highlighted past: the configured one, or else the earliest target date.
+// It is empty if there is neither.
+func (c *Config) HighlightTarget() string {
+	if c.Highlights.Target != "" || len(c.TargetDates) == 0 {
+		return c.Highlights.Target
+	}
+	return slices.Min(c.TargetDates)
+}
+
+// IsCountSizing checks if issues are sized by count (throughput) rather than by the size field.
+func (c *Config) IsCountSizing() bool {
+	return c.Jira.SizingMode == SizingCount
+}
+
+// SizeUnit names the unit that sizes, earned value and velocity are measured in.
+func (c *Config) SizeUnit() string {
+	if c.IsCountSizing() {
+		return "items"
+	}
+	return "points"
+}
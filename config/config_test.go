@@ -106,6 +106,88 @@ func TestConfigValidate(t *testing.T) {
 			errMessage: `'JQL' failed on the 'required' tag`,
 		},
 
+		{
+			name: "unknown period",
+			config: Config{
+				OutputFile:     "OutputFile",
+				StartDate:      "2024-01-01",
+				JQL:            "Jql",
+				Period:         "quarterly",
+				MovingAvgWeeks: 1,
+				Jira: JiraConfig{
+					JiraURL:              "https://example.atlassian.net",
+					Username:             "UserName",
+					APIToken:             "ApiToken",
+					SizeField:            "SizeField",
+					PercentCompleteField: "PercentCompleteField",
+					DoneStatuses:         []string{"Done"},
+				},
+			},
+			errMessage: `'Period' failed on the 'oneof' tag`,
+		},
+
+		{
+			name: "unknown period anchor",
+			config: Config{
+				OutputFile:     "OutputFile",
+				StartDate:      "2024-01-01",
+				JQL:            "Jql",
+				Period:         "weekly",
+				PeriodAnchor:   "fri",
+				MovingAvgWeeks: 1,
+				Jira: JiraConfig{
+					JiraURL:              "https://example.atlassian.net",
+					Username:             "UserName",
+					APIToken:             "ApiToken",
+					SizeField:            "SizeField",
+					PercentCompleteField: "PercentCompleteField",
+					DoneStatuses:         []string{"Done"},
+				},
+			},
+			errMessage: `'PeriodAnchor' failed on the 'oneof' tag`,
+		},
+
+		{
+			name: "period anchor on monthly periods",
+			config: Config{
+				OutputFile:     "OutputFile",
+				StartDate:      "2024-01-01",
+				JQL:            "Jql",
+				Period:         PeriodMonthly,
+				PeriodAnchor:   "friday",
+				MovingAvgWeeks: 1,
+				Jira: JiraConfig{
+					JiraURL:              "https://example.atlassian.net",
+					Username:             "UserName",
+					APIToken:             "ApiToken",
+					SizeField:            "SizeField",
+					PercentCompleteField: "PercentCompleteField",
+					DoneStatuses:         []string{"Done"},
+				},
+			},
+			errMessage: `period_anchor only applies to weekly and biweekly periods`,
+		},
+
+		{
+			name: "sprint periods without a board",
+			config: Config{
+				OutputFile:     "OutputFile",
+				StartDate:      "2024-01-01",
+				JQL:            "Jql",
+				Period:         PeriodSprint,
+				MovingAvgWeeks: 1,
+				Jira: JiraConfig{
+					JiraURL:              "https://example.atlassian.net",
+					Username:             "UserName",
+					APIToken:             "ApiToken",
+					SizeField:            "SizeField",
+					PercentCompleteField: "PercentCompleteField",
+					DoneStatuses:         []string{"Done"},
+				},
+			},
+			errMessage: `board_id is required when period is sprint`,
+		},
+
 		{
 			name: "missing moving average weeks",
 			config: Config{
@@ -125,6 +207,306 @@ func TestConfigValidate(t *testing.T) {
 			errMessage: `'MovingAvgWeeks' failed on the 'required' tag`,
 		},
 
+		{
+			name: "malformed target date",
+			config: Config{
+				OutputFile:     "OutputFile",
+				StartDate:      "2024-01-01",
+				JQL:            "Jql",
+				MovingAvgWeeks: 1,
+				TargetDates:    []string{"2024-03-31", "03-31-2024"},
+				Jira: JiraConfig{
+					JiraURL:              "https://example.atlassian.net",
+					Username:             "UserName",
+					APIToken:             "ApiToken",
+					SizeField:            "SizeField",
+					PercentCompleteField: "PercentCompleteField",
+					DoneStatuses:         []string{"Done"},
+				},
+			},
+			errMessage: `'TargetDates[1]' failed on the 'datetime' tag`,
+		},
+
+		{
+			name: "zero backtest window",
+			config: Config{
+				OutputFile:     "OutputFile",
+				StartDate:      "2024-01-01",
+				JQL:            "Jql",
+				MovingAvgWeeks: 1,
+				Backtest:       BacktestConfig{Enabled: true, Windows: []uint{4, 0}},
+				Jira: JiraConfig{
+					JiraURL:              "https://example.atlassian.net",
+					Username:             "UserName",
+					APIToken:             "ApiToken",
+					SizeField:            "SizeField",
+					PercentCompleteField: "PercentCompleteField",
+					DoneStatuses:         []string{"Done"},
+				},
+			},
+			errMessage: `'Windows[1]' failed on the 'gt' tag`,
+		},
+
+		{
+			name: "invalid chart format",
+			config: Config{
+				OutputFile:     "OutputFile",
+				StartDate:      "2024-01-01",
+				JQL:            "Jql",
+				MovingAvgWeeks: 1,
+				Charts:         ChartsConfig{OutputDir: "charts", Formats: []string{"svg", "gif"}},
+				Jira: JiraConfig{
+					JiraURL:              "https://example.atlassian.net",
+					Username:             "UserName",
+					APIToken:             "ApiToken",
+					SizeField:            "SizeField",
+					PercentCompleteField: "PercentCompleteField",
+					DoneStatuses:         []string{"Done"},
+				},
+			},
+			errMessage: `'Formats[1]' failed on the 'oneof' tag`,
+		},
+
+		{
+			name: "unknown chart color",
+			config: Config{
+				OutputFile:     "OutputFile",
+				StartDate:      "2024-01-01",
+				JQL:            "Jql",
+				MovingAvgWeeks: 1,
+				Charts:         ChartsConfig{OutputDir: "charts", Colors: map[string]string{"remianing": "#ff0000"}},
+				Jira: JiraConfig{
+					JiraURL:              "https://example.atlassian.net",
+					Username:             "UserName",
+					APIToken:             "ApiToken",
+					SizeField:            "SizeField",
+					PercentCompleteField: "PercentCompleteField",
+					DoneStatuses:         []string{"Done"},
+				},
+			},
+			errMessage: `failed on the 'oneof' tag`,
+		},
+
+		{
+			name: "invalid chart color",
+			config: Config{
+				OutputFile:     "OutputFile",
+				StartDate:      "2024-01-01",
+				JQL:            "Jql",
+				MovingAvgWeeks: 1,
+				Charts:         ChartsConfig{OutputDir: "charts", Colors: map[string]string{"remaining": "red"}},
+				Jira: JiraConfig{
+					JiraURL:              "https://example.atlassian.net",
+					Username:             "UserName",
+					APIToken:             "ApiToken",
+					SizeField:            "SizeField",
+					PercentCompleteField: "PercentCompleteField",
+					DoneStatuses:         []string{"Done"},
+				},
+			},
+			errMessage: `'Colors[remaining]' failed on the 'hexcolor' tag`,
+		},
+
+		{
+			name: "unknown cumulative flow measure",
+			config: Config{
+				OutputFile:     "OutputFile",
+				StartDate:      "2024-01-01",
+				JQL:            "Jql",
+				MovingAvgWeeks: 1,
+				CumulativeFlow: FlowConfig{Measure: "points"},
+				Jira: JiraConfig{
+					JiraURL:              "https://example.atlassian.net",
+					Username:             "UserName",
+					APIToken:             "ApiToken",
+					SizeField:            "SizeField",
+					PercentCompleteField: "PercentCompleteField",
+					DoneStatuses:         []string{"Done"},
+				},
+			},
+			errMessage: `'Measure' failed on the 'oneof' tag`,
+		},
+
+		{
+			name: "unknown breakdown",
+			config: Config{
+				OutputFile:     "OutputFile",
+				StartDate:      "2024-01-01",
+				JQL:            "Jql",
+				MovingAvgWeeks: 1,
+				Breakdowns:     []string{"assignee", "reporter"},
+				Jira: JiraConfig{
+					JiraURL:              "https://example.atlassian.net",
+					Username:             "UserName",
+					APIToken:             "ApiToken",
+					SizeField:            "SizeField",
+					PercentCompleteField: "PercentCompleteField",
+					DoneStatuses:         []string{"Done"},
+				},
+			},
+			errMessage: `'Breakdowns[1]' failed on the 'oneof' tag`,
+		},
+
+		{
+			name: "team breakdown without a team field",
+			config: Config{
+				OutputFile:     "OutputFile",
+				StartDate:      "2024-01-01",
+				JQL:            "Jql",
+				MovingAvgWeeks: 1,
+				Breakdowns:     []string{"team"},
+				Jira: JiraConfig{
+					JiraURL:              "https://example.atlassian.net",
+					Username:             "UserName",
+					APIToken:             "ApiToken",
+					SizeField:            "SizeField",
+					PercentCompleteField: "PercentCompleteField",
+					DoneStatuses:         []string{"Done"},
+				},
+			},
+			errMessage: `team_field is required`,
+		},
+
+		{
+			name: "unknown formulas mode",
+			config: Config{
+				OutputFile:     "OutputFile",
+				Formulas:       "static",
+				StartDate:      "2024-01-01",
+				JQL:            "Jql",
+				MovingAvgWeeks: 1,
+				Jira: JiraConfig{
+					JiraURL:              "https://example.atlassian.net",
+					Username:             "UserName",
+					APIToken:             "ApiToken",
+					SizeField:            "SizeField",
+					PercentCompleteField: "PercentCompleteField",
+					DoneStatuses:         []string{"Done"},
+				},
+			},
+			errMessage: `'Formulas' failed on the 'oneof' tag`,
+		},
+
+		{
+			name: "unknown highlight rule",
+			config: Config{
+				OutputFile:     "OutputFile",
+				StartDate:      "2024-01-01",
+				JQL:            "Jql",
+				MovingAvgWeeks: 1,
+				Highlights:     HighlightsConfig{Rules: []string{HighlightStalled, "late"}},
+				Jira: JiraConfig{
+					JiraURL:              "https://example.atlassian.net",
+					Username:             "UserName",
+					APIToken:             "ApiToken",
+					SizeField:            "SizeField",
+					PercentCompleteField: "PercentCompleteField",
+					DoneStatuses:         []string{"Done"},
+				},
+			},
+			errMessage: `'Rules[1]' failed on the 'oneof' tag`,
+		},
+
+		{
+			name: "invalid highlight target",
+			config: Config{
+				OutputFile:     "OutputFile",
+				StartDate:      "2024-01-01",
+				JQL:            "Jql",
+				MovingAvgWeeks: 1,
+				Highlights:     HighlightsConfig{Target: "2024-13-01"},
+				Jira: JiraConfig{
+					JiraURL:              "https://example.atlassian.net",
+					Username:             "UserName",
+					APIToken:             "ApiToken",
+					SizeField:            "SizeField",
+					PercentCompleteField: "PercentCompleteField",
+					DoneStatuses:         []string{"Done"},
+				},
+			},
+			errMessage: `'Target' failed on the 'datetime' tag`,
+		},
+
+		{
+			name: "missing template",
+			config: Config{
+				OutputFile:     "OutputFile",
+				Template:       "missing.xlsx",
+				StartDate:      "2024-01-01",
+				JQL:            "Jql",
+				MovingAvgWeeks: 1,
+				Jira: JiraConfig{
+					JiraURL:              "https://example.atlassian.net",
+					Username:             "UserName",
+					APIToken:             "ApiToken",
+					SizeField:            "SizeField",
+					PercentCompleteField: "PercentCompleteField",
+					DoneStatuses:         []string{"Done"},
+				},
+			},
+			errMessage: `'Template' failed on the 'file' tag`,
+		},
+
+		{
+			name: "column without a field",
+			config: Config{
+				OutputFile:     "OutputFile",
+				StartDate:      "2024-01-01",
+				JQL:            "Jql",
+				MovingAvgWeeks: 1,
+				Columns:        []ColumnConfig{{Field: "priority"}, {Header: "Risk"}},
+				Jira: JiraConfig{
+					JiraURL:              "https://example.atlassian.net",
+					Username:             "UserName",
+					APIToken:             "ApiToken",
+					SizeField:            "SizeField",
+					PercentCompleteField: "PercentCompleteField",
+					DoneStatuses:         []string{"Done"},
+				},
+			},
+			errMessage: `'Config.Columns[1].Field' Error:Field validation for 'Field' failed on the 'required' tag`,
+		},
+
+		{
+			name: "sprint column without a sprint field",
+			config: Config{
+				OutputFile:     "OutputFile",
+				StartDate:      "2024-01-01",
+				JQL:            "Jql",
+				MovingAvgWeeks: 1,
+				Columns:        []ColumnConfig{{Field: ColumnSprint}},
+				Jira: JiraConfig{
+					JiraURL:              "https://example.atlassian.net",
+					Username:             "UserName",
+					APIToken:             "ApiToken",
+					SizeField:            "SizeField",
+					PercentCompleteField: "PercentCompleteField",
+					DoneStatuses:         []string{"Done"},
+				},
+			},
+			errMessage: `sprint_field is required for a sprint column`,
+		},
+
+		{
+			name: "unknown group by field",
+			config: Config{
+				OutputFile:     "OutputFile",
+				StartDate:      "2024-01-01",
+				JQL:            "Jql",
+				MovingAvgWeeks: 1,
+				GroupBy:        "reporter",
+				Jira: JiraConfig{
+					JiraURL:              "https://example.atlassian.net",
+					Username:             "UserName",
+					APIToken:             "ApiToken",
+					SizeField:            "SizeField",
+					PercentCompleteField: "PercentCompleteField",
+					DoneStatuses:         []string{"Done"},
+				},
+			},
+			errMessage: `Field validation for 'GroupBy' failed`,
+		},
+
 		{
 			name: "missing Jira URL",
 			config: Config{
@@ -217,7 +599,67 @@ func TestConfigValidate(t *testing.T) {
 					DoneStatuses:         []string{"Done"},
 				},
 			},
-			errMessage: `'SizeField' failed on the 'required' tag`,
+			errMessage: `'SizeField' failed on the 'required_unless' tag`,
+		},
+
+		{
+			name: "missing size field when sizing by count",
+			config: Config{
+				OutputFile:     "OutputFile",
+				StartDate:      "2024-01-01",
+				JQL:            "Jql",
+				MovingAvgWeeks: 1,
+				Jira: JiraConfig{
+					JiraURL:              "https://example.atlassian.net",
+					Username:             "UserName",
+					APIToken:             "ApiToken",
+					SizeField:            "",
+					SizingMode:           SizingCount,
+					TypeWeights:          map[string]float64{"Bug": 0.5},
+					PercentCompleteField: "PercentCompleteField",
+					DoneStatuses:         []string{"Done"},
+				},
+			},
+		},
+
+		{
+			name: "unknown sizing mode",
+			config: Config{
+				OutputFile:     "OutputFile",
+				StartDate:      "2024-01-01",
+				JQL:            "Jql",
+				MovingAvgWeeks: 1,
+				Jira: JiraConfig{
+					JiraURL:              "https://example.atlassian.net",
+					Username:             "UserName",
+					APIToken:             "ApiToken",
+					SizeField:            "SizeField",
+					SizingMode:           "tshirt",
+					PercentCompleteField: "PercentCompleteField",
+					DoneStatuses:         []string{"Done"},
+				},
+			},
+			errMessage: `'SizingMode' failed on the 'oneof' tag`,
+		},
+
+		{
+			name: "negative type weight",
+			config: Config{
+				OutputFile:     "OutputFile",
+				StartDate:      "2024-01-01",
+				JQL:            "Jql",
+				MovingAvgWeeks: 1,
+				Jira: JiraConfig{
+					JiraURL:              "https://example.atlassian.net",
+					Username:             "UserName",
+					APIToken:             "ApiToken",
+					SizingMode:           SizingCount,
+					TypeWeights:          map[string]float64{"Bug": -1},
+					PercentCompleteField: "PercentCompleteField",
+					DoneStatuses:         []string{"Done"},
+				},
+			},
+			errMessage: `'TypeWeights[Bug]' failed on the 'gte' tag`,
 		},
 
 		{
@@ -289,3 +731,47 @@ func TestConfigValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestOutputFormatOrDefault(t *testing.T) {
+	tests := []struct {
+		name         string
+		outputFile   string
+		outputFormat string
+		format       string
+	}{
+		{name: "excel by default", outputFile: "burndown.xlsx", format: FormatXLSX},
+		{name: "no extension", outputFile: "burndown", format: FormatXLSX},
+		{name: "csv by extension", outputFile: "burndown.CSV", format: FormatCSV},
+		{name: "json by extension", outputFile: "burndown.json", format: FormatJSON},
+		{name: "html by extension", outputFile: "burndown.html", format: FormatHTML},
+		{name: "markdown by extension", outputFile: "status.md", format: FormatMarkdown},
+		{name: "explicit format wins", outputFile: "burndown.xlsx", outputFormat: FormatCSV, format: FormatCSV},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := Config{OutputFile: tt.outputFile, OutputFormat: tt.outputFormat}
+			assert.Equal(t, tt.format, config.OutputFormatOrDefault())
+		})
+	}
+}
+
+func TestHighlightTarget(t *testing.T) {
+	tests := []struct {
+		name        string
+		target      string
+		targetDates []string
+		want        string
+	}{
+		{name: "no target", want: ""},
+		{name: "earliest target date", targetDates: []string{"2025-06-30", "2025-03-31"}, want: "2025-03-31"},
+		{name: "configured target wins", target: "2025-09-30", targetDates: []string{"2025-03-31"}, want: "2025-09-30"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := Config{TargetDates: tt.targetDates, Highlights: HighlightsConfig{Target: tt.target}}
+			assert.Equal(t, tt.want, config.HighlightTarget())
+		})
+	}
+}
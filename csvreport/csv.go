@@ -0,0 +1,134 @@
+// Package csvreport provides functionality for exporting the burndown as CSV files of computed values.
+package csvreport
+
+import (
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"go-burndown/burndown"
+	"go-burndown/config"
+	"go-burndown/jira"
+)
+
+// GenerateCSVReport writes the burndown as CSV files next to the configured output file, one per table:
+// the issues' progress in long format (one row per issue per period), and the projections (one row per period).
+// Unlike the Excel report, every value is computed, so the files can be loaded without recalculation.
+func GenerateCSVReport(config *config.Config, issues []jira.Issue, timeline burndown.Timeline) (files []string, err error) {
+	series, err := burndown.NewSeries(config, issues, timeline)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	issuesFile, projectionsFile := FileNames(config.OutputFile)
+
+	if err := writeCSV(issuesFile, issueRecords(config, series)); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if err := writeCSV(projectionsFile, projectionRecords(series)); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return []string{issuesFile, projectionsFile}, nil
+}
+
+// FileNames derives the per-table file names from the output file, e.g. "burndown.csv" becomes
+// "burndown-issues.csv" and "burndown-projections.csv".
+func FileNames(outputFile string) (issuesFile, projectionsFile string) {
+	base := strings.TrimSuffix(outputFile, filepath.Ext(outputFile))
+	return base + "-issues.csv", base + "-projections.csv"
+}
+
+// issueRecords lays out each issue's progress in long format, oldest period first.
+func issueRecords(config *config.Config, series burndown.Series) [][]string {
+	records := [][]string{{
+		"issue_key", "url", "summary", "type", "status", "assignee", "size", "period_end", "percent_complete", "earned_value",
+	}}
+	for i := range series.Issues {
+		progress := &series.Issues[i]
+		issue := progress.Issue
+		for periodIndex, period := range series.Timeline.Periods {
+			records = append(records, []string{
+				issue.Key,
+				config.TicketUrl(issue.Key),
+				issue.Fields.Summary,
+				issue.GetType(),
+				issue.GetStatus(),
+				issue.Fields.Assignee.DisplayName,
+				formatFloat(progress.Size),
+				formatDate(period.End),
+				formatFloat(progress.PercentComplete[periodIndex]),
+				formatFloat(progress.EarnedValue(periodIndex)),
+			})
+		}
+	}
+	return records
+}
+
+// projectionRecords lays out the projections, one row per period, leaving values blank where the Projections sheet would.
+func projectionRecords(series burndown.Series) [][]string {
+	records := [][]string{{
+		"period_end", "completed", "remaining", "scope", "velocity", "avg_velocity", "std_dev",
+		"fast", "mean", "slow", "fast_velocity", "slow_velocity", "added", "removed", "reestimated",
+	}}
+	for periodIndex, point := range series.Points {
+		record := []string{
+			formatDate(point.Period.End),
+			formatFloat(point.Completed),
+			formatFloat(point.Remaining),
+			formatFloat(point.Scope),
+			"", "", "", "", "", "", "", "",
+			formatFloat(point.Added),
+			formatFloat(point.Removed),
+			formatFloat(point.Reestimated),
+		}
+		if point.HasVelocity {
+			record[4] = formatFloat(point.Velocity)
+		}
+		if point.HasAvg {
+			record[5] = formatFloat(point.AvgVelocity)
+		}
+		if forecast, ok := series.Forecast(periodIndex); ok {
+			record[6] = formatFloat(point.StdDev)
+			record[7] = formatDate(forecast.Fast)
+			record[8] = formatDate(forecast.Mean)
+			record[9] = formatDate(forecast.Slow)
+			record[10] = formatFloat(point.AvgVelocity + point.StdDev)
+			record[11] = formatFloat(point.AvgVelocity - point.StdDev)
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+func writeCSV(filename string, records [][]string) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer func() { _ = file.Close() }()
+
+	writer := csv.NewWriter(file)
+	if err := writer.WriteAll(records); err != nil {
+		return errors.WithStack(err)
+	}
+
+	return errors.WithStack(file.Close())
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// formatDate formats a date, leaving it blank if there is none (such as a forecast at zero velocity).
+func formatDate(date time.Time) string {
+	if date.IsZero() {
+		return ""
+	}
+	return date.Format("2006-01-02")
+}
@@ -0,0 +1,155 @@
+package excel
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/xuri/excelize/v2"
+
+	"go-burndown/burndown"
+	"go-burndown/config"
+)
+
+// writeBacktestSheets adds the backtest sheets, if backtesting is enabled: the forecast each method made at every past period,
+// and how accurate each method was overall.
+func writeBacktestSheets(f *excelize.File, config *config.Config, series burndown.Series, dateStyleID, numStyleID int) error {
+	if !config.Backtest.Enabled {
+		return nil
+	}
+	timeline := series.Timeline
+
+	backtest, err := burndown.NewBacktest(config, series)
+	if err != nil && !errors.Is(err, burndown.ErrNotEnoughHistory) {
+		return errors.WithStack(err)
+	}
+
+	// The forecasts made at each past period.
+	backtestSheet := "Backtest"
+	if _, err := f.NewSheet(backtestSheet); err != nil {
+		return errors.WithStack(err)
+	}
+
+	// Early in a project there is nothing to compare the forecasts against yet, which the sheet says rather than
+	// failing the report.
+	if errors.Is(err, burndown.ErrNotEnoughHistory) {
+		note := "Not enough history to backtest yet: there is no forecast to compare against until there are three velocities."
+		return errors.WithStack(f.SetCellValue(backtestSheet, "A1", note))
+	}
+
+	referenceHeader := "Forecast Now"
+	if backtest.ReferenceIsActual {
+		referenceHeader = "Actual"
+	}
+	headers := []string{"Date", unitHeader(config, "Remaining"), referenceHeader}
+	for _, method := range backtest.Methods {
+		name := backtestMethodName(method, timeline)
+		headers = append(headers, name, name+" Err (days)")
+	}
+	for i, header := range headers {
+		cell, err := excelize.CoordinatesToCellName(i+1, 1)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		if err := f.SetCellValue(backtestSheet, cell, header); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+
+	for rowIndex, row := range backtest.Rows {
+		rowNum := rowIndex + 2
+
+		if err := f.SetCellValue(backtestSheet, fmt.Sprintf("A%d", rowNum), row.Point.Period.End); err != nil {
+			return errors.WithStack(err)
+		}
+		if err := f.SetCellValue(backtestSheet, fmt.Sprintf("B%d", rowNum), row.Point.Remaining); err != nil {
+			return errors.WithStack(err)
+		}
+		if err := f.SetCellValue(backtestSheet, fmt.Sprintf("C%d", rowNum), backtest.Reference); err != nil {
+			return errors.WithStack(err)
+		}
+		if err := f.SetCellStyle(backtestSheet, fmt.Sprintf("A%d", rowNum), fmt.Sprintf("A%d", rowNum), dateStyleID); err != nil {
+			return errors.WithStack(err)
+		}
+		if err := f.SetCellStyle(backtestSheet, fmt.Sprintf("B%d", rowNum), fmt.Sprintf("B%d", rowNum), numStyleID); err != nil {
+			return errors.WithStack(err)
+		}
+		if err := f.SetCellStyle(backtestSheet, fmt.Sprintf("C%d", rowNum), fmt.Sprintf("C%d", rowNum), dateStyleID); err != nil {
+			return errors.WithStack(err)
+		}
+
+		// Forecast and error pairs, blank where the method had no forecast.
+		for methodIndex := range backtest.Methods {
+			if row.Forecasts[methodIndex].IsZero() {
+				continue
+			}
+			forecastCell, err := excelize.CoordinatesToCellName(4+methodIndex*2, rowNum)
+			if err != nil {
+				return errors.WithStack(err)
+			}
+			errorCell, err := excelize.CoordinatesToCellName(5+methodIndex*2, rowNum)
+			if err != nil {
+				return errors.WithStack(err)
+			}
+			if err := f.SetCellValue(backtestSheet, forecastCell, row.Forecasts[methodIndex]); err != nil {
+				return errors.WithStack(err)
+			}
+			if err := f.SetCellStyle(backtestSheet, forecastCell, forecastCell, dateStyleID); err != nil {
+				return errors.WithStack(err)
+			}
+			if err := f.SetCellValue(backtestSheet, errorCell, row.Errors[methodIndex]); err != nil {
+				return errors.WithStack(err)
+			}
+			if err := f.SetCellStyle(backtestSheet, errorCell, errorCell, numStyleID); err != nil {
+				return errors.WithStack(err)
+			}
+		}
+	}
+
+	// How accurate each method was, most accurate first.
+	accuracySheet := "Accuracy"
+	if _, err := f.NewSheet(accuracySheet); err != nil {
+		return errors.WithStack(err)
+	}
+
+	accuracyHeaders := []string{"Method", "Window", "Forecasts", "Mean Abs Err (days)", "Bias (days)"}
+	for i, header := range accuracyHeaders {
+		cell, err := excelize.CoordinatesToCellName(i+1, 1)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		if err := f.SetCellValue(accuracySheet, cell, header); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+
+	for rowIndex, accuracy := range backtest.Accuracy {
+		rowNum := rowIndex + 2
+		values := []interface{}{accuracy.Method.Name, fmt.Sprintf("%d%s", accuracy.Method.Window, timeline.Unit), accuracy.Forecasts}
+		if accuracy.Forecasts > 0 {
+			values = append(values, accuracy.MeanAbsError, accuracy.MeanError)
+		}
+		for i, value := range values {
+			cell, err := excelize.CoordinatesToCellName(i+1, rowNum)
+			if err != nil {
+				return errors.WithStack(err)
+			}
+			if err := f.SetCellValue(accuracySheet, cell, value); err != nil {
+				return errors.WithStack(err)
+			}
+		}
+		if err := f.SetCellStyle(accuracySheet, fmt.Sprintf("D%d", rowNum), fmt.Sprintf("E%d", rowNum), numStyleID); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+
+	return nil
+}
+
+// backtestMethodName abbreviates a method and window for column headers, e.g. "MA 12w" or "MC 4w".
+func backtestMethodName(method burndown.BacktestMethod, timeline burndown.Timeline) string {
+	abbreviation := "MA"
+	if method.Name == burndown.BacktestMonteCarlo {
+		abbreviation = "MC"
+	}
+	return fmt.Sprintf("%s %d%s", abbreviation, method.Window, timeline.Unit)
+}
@@ -0,0 +1,75 @@
+package excel
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/xuri/excelize/v2"
+
+	"go-burndown/burndown"
+	"go-burndown/config"
+)
+
+// writeBreakdownSheets adds a sheet per configured breakdown, such as "By Assignee", with one row per group per period.
+func writeBreakdownSheets(f *excelize.File, config *config.Config, series burndown.Series, numStyleID int) error {
+	for _, dimension := range config.Breakdowns {
+		breakdown, err := burndown.NewBreakdown(config, series, dimension)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		if err := writeBreakdownSheet(f, config, breakdown, numStyleID); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	return nil
+}
+
+// writeBreakdownSheet adds the sheet for one breakdown. Groups are listed one after another, each oldest period first.
+func writeBreakdownSheet(f *excelize.File, config *config.Config, breakdown burndown.Breakdown, numStyleID int) error {
+	dimensionTitle := strings.ToUpper(breakdown.Dimension[:1]) + breakdown.Dimension[1:]
+	breakdownSheet := "By " + dimensionTitle
+	if _, err := f.NewSheet(breakdownSheet); err != nil {
+		return errors.WithStack(err)
+	}
+
+	headers := []string{dimensionTitle, "Date", unitHeader(config, "Completed"), unitHeader(config, "Remaining"), unitHeader(config, "Velocity")}
+	for i, header := range headers {
+		cell, err := excelize.CoordinatesToCellName(i+1, 1)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		if err := f.SetCellValue(breakdownSheet, cell, header); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+
+	rowNum := 2
+	for _, group := range breakdown.Groups {
+		for _, point := range group.Points {
+			if err := f.SetCellValue(breakdownSheet, fmt.Sprintf("A%d", rowNum), group.Name); err != nil {
+				return errors.WithStack(err)
+			}
+			if err := f.SetCellValue(breakdownSheet, fmt.Sprintf("B%d", rowNum), point.Period.End.Format("2006-01-02")); err != nil {
+				return errors.WithStack(err)
+			}
+			if err := f.SetCellValue(breakdownSheet, fmt.Sprintf("C%d", rowNum), point.Completed); err != nil {
+				return errors.WithStack(err)
+			}
+			if err := f.SetCellValue(breakdownSheet, fmt.Sprintf("D%d", rowNum), point.Remaining); err != nil {
+				return errors.WithStack(err)
+			}
+			if point.HasVelocity {
+				if err := f.SetCellValue(breakdownSheet, fmt.Sprintf("E%d", rowNum), point.Velocity); err != nil {
+					return errors.WithStack(err)
+				}
+			}
+			if err := f.SetCellStyle(breakdownSheet, fmt.Sprintf("C%d", rowNum), fmt.Sprintf("E%d", rowNum), numStyleID); err != nil {
+				return errors.WithStack(err)
+			}
+			rowNum++
+		}
+	}
+
+	return nil
+}
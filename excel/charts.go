@@ -0,0 +1,91 @@
+package excel
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/xuri/excelize/v2"
+
+	"go-burndown/burndown"
+)
+
+// writeChartsSheet adds a sheet of native charts that reference the live Projections ranges:
+// a burndown of the remaining work, a burnup of the completed work against the total scope,
+// and the velocity per period with its moving average overlaid.
+func writeChartsSheet(f *excelize.File, projectionsSheet string, timeline burndown.Timeline) error {
+	if len(timeline.Periods) == 0 {
+		return nil
+	}
+
+	chartsSheet := "Charts"
+	if _, err := f.NewSheet(chartsSheet); err != nil {
+		return errors.WithStack(err)
+	}
+
+	// The Projections rows that hold period data.
+	lastRow := len(timeline.Periods) + 1
+	column := func(col string) string {
+		return fmt.Sprintf("%s!$%s$2:$%s$%d", projectionsSheet, col, col, lastRow)
+	}
+	header := func(col string) string {
+		return fmt.Sprintf("%s!$%s$1", projectionsSheet, col)
+	}
+	dates := column(_COL_DATE)
+
+	dimension := excelize.ChartDimension{Width: 960, Height: 360}
+	legend := excelize.ChartLegend{Position: "bottom"}
+
+	// Burndown of the remaining work.
+	burndownChart := &excelize.Chart{
+		Type: excelize.Line,
+		Series: []excelize.ChartSeries{
+			{Name: header(_COL_REMAINING), Categories: dates, Values: column(_COL_REMAINING)},
+		},
+		Title:     []excelize.RichTextRun{{Text: "Burndown"}},
+		Legend:    legend,
+		Dimension: dimension,
+		YAxis:     excelize.ChartAxis{MajorGridLines: true},
+	}
+	if err := f.AddChart(chartsSheet, "A1", burndownChart); err != nil {
+		return errors.WithStack(err)
+	}
+
+	// Burnup of the completed work against the total scope.
+	burnupChart := &excelize.Chart{
+		Type: excelize.Line,
+		Series: []excelize.ChartSeries{
+			{Name: header(_COL_COMPLETED), Categories: dates, Values: column(_COL_COMPLETED)},
+			{Name: header(_COL_SCOPE), Categories: dates, Values: column(_COL_SCOPE)},
+		},
+		Title:     []excelize.RichTextRun{{Text: "Burnup"}},
+		Legend:    legend,
+		Dimension: dimension,
+		YAxis:     excelize.ChartAxis{MajorGridLines: true},
+	}
+	if err := f.AddChart(chartsSheet, "A20", burnupChart); err != nil {
+		return errors.WithStack(err)
+	}
+
+	// Velocity bars with the moving average overlaid.
+	velocityChart := &excelize.Chart{
+		Type: excelize.Col,
+		Series: []excelize.ChartSeries{
+			{Name: header(_COL_VELOCITY), Categories: dates, Values: column(_COL_VELOCITY)},
+		},
+		Title:     []excelize.RichTextRun{{Text: "Velocity"}},
+		Legend:    legend,
+		Dimension: dimension,
+		YAxis:     excelize.ChartAxis{MajorGridLines: true},
+	}
+	avgVelocityChart := &excelize.Chart{
+		Type: excelize.Line,
+		Series: []excelize.ChartSeries{
+			{Name: header(_COL_AVG_VELOCITY), Categories: dates, Values: column(_COL_AVG_VELOCITY)},
+		},
+	}
+	if err := f.AddChart(chartsSheet, "A39", velocityChart, avgVelocityChart); err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
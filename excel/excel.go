@@ -3,49 +3,49 @@ package excel
 
 import (
 	"fmt"
-	"time"
+	"slices"
+	"strconv"
 
 	"github.com/pkg/errors"
 	"github.com/xuri/excelize/v2"
 
+	"go-burndown/burndown"
 	"go-burndown/config"
 	"go-burndown/jira"
 )
 
-// GenerateExcelReport creates an Excel report from Jira issues and saves it to a file.
-func GenerateExcelReport(config *config.Config, issues []jira.Issue) error {
-	movingAvgWeeks := config.MovingAvgWeeks
+// GenerateExcelReport creates an Excel report from Jira issues, bucketed into the timeline's periods, and saves it to a file.
+func GenerateExcelReport(config *config.Config, issues []jira.Issue, timeline burndown.Timeline) error {
+	movingAvgPeriods := config.MovingAvgWeeks
 
-	// Create a new Excel file
-	f := excelize.NewFile()
-
-	// Create first sheet: Issues with weekly progress data
+	// Create the workbook, from the template if there is one, or open the existing one when updating, with the first sheet for issues with per-period progress data.
 	workSheet := "Work"
-	if _, err := f.NewSheet(workSheet); err != nil {
-		return errors.Wrap(err, "failed to create work sheet")
-	}
-
-	// Calculate start date
-	startDate, err := time.Parse("2006-01-02", config.StartDate)
+	f, userSheets, annotations, err := openWorkbook(config, workSheet, workHeaders(config, timeline.Periods))
 	if err != nil {
-		return errors.Wrapf(err, "invalid start date format: %s", config.StartDate)
+		return errors.WithStack(err)
 	}
+	defer cachedTypes.Delete(f)
+	keptSheets := slices.DeleteFunc(slices.Clone(userSheets), func(sheet string) bool {
+		return sheet == workSheet
+	})
 
-	// Generate weekly dates: oldest on the right, newest on the left
-	currentDate := time.Now()
-	weeks := []time.Time{}
-
-	// Calculate complete weeks from start date to current date
-	for d := startDate; d.Before(currentDate) || d.Equal(currentDate); d = d.AddDate(0, 0, 7) {
-		weeks = append(weeks, d)
+	// The Summary sheet goes just ahead of the Work sheet and is what opens first. It is filled in once the
+	// Projections sheet it draws on is written.
+	summarySheet := "Summary"
+	if _, err := f.NewSheet(summarySheet); err != nil {
+		return errors.WithStack(err)
 	}
-
-	// Create reversed weeks for Work sheet columns (oldest on right)
-	reversedWeeks := make([]time.Time, len(weeks))
-	copy(reversedWeeks, weeks)
-	for i, j := 0, len(reversedWeeks)-1; i < j; i, j = i+1, j-1 {
-		reversedWeeks[i], reversedWeeks[j] = reversedWeeks[j], reversedWeeks[i]
+	if err := f.MoveSheet(summarySheet, workSheet); err != nil {
+		return errors.WithStack(err)
 	}
+	summaryIndex, err := f.GetSheetIndex(summarySheet)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	f.SetActiveSheet(summaryIndex)
+
+	// Reporting periods, oldest first.
+	periods := timeline.Periods
 
 	// Hyperlink style (blue, underlined).
 	hyperlinkStyleID, err := f.NewStyle(&excelize.Style{
@@ -79,160 +79,134 @@ func GenerateExcelReport(config *config.Config, issues []jira.Issue) error {
 		return err
 	}
 
-	// Create headers: Issue Key, Summary, Type, Status, Assignee, Size, then weekly pairs
-	headers := []string{"Issue Key", "Summary", "Type", "Status", "Assignee", "Size"}
+	// A template's styles replace the built-in ones.
+	if hyperlinkStyleID, err = templateStyle(f, _TEMPLATE_HYPERLINK_STYLE, hyperlinkStyleID); err != nil {
+		return errors.WithStack(err)
+	}
+	if percentStyleID, err = templateStyle(f, _TEMPLATE_PERCENT_STYLE, percentStyleID); err != nil {
+		return errors.WithStack(err)
+	}
+	if dateStyleID, err = templateStyle(f, _TEMPLATE_DATE_STYLE, dateStyleID); err != nil {
+		return errors.WithStack(err)
+	}
+	if numStyleID, err = templateStyle(f, _TEMPLATE_NUMBER_STYLE, numStyleID); err != nil {
+		return errors.WithStack(err)
+	}
 
-	// Add weekly headers (oldest on right, newest on left) - compact format
-	for _, weekDate := range reversedWeeks {
-		dateStr := weekDate.Format("01-02")
-		headers = append(headers, fmt.Sprintf("%% %s", dateStr), fmt.Sprintf("EV %s", dateStr))
+	// Progress and scope come from replaying each issue's history, which formulas can't do.
+	series, err := burndown.NewSeries(config, issues, timeline)
+	if err != nil {
+		return errors.WithStack(err)
 	}
 
-	// Set headers
-	for i, header := range headers {
-		cell, _ := excelize.CoordinatesToCellName(i+1, 1)
-		if err := f.SetCellValue(workSheet, cell, header); err != nil {
-			return errors.WithStack(err)
-		}
+	// Issues with per-period progress data. A Work sheet from a template keeps its own layout.
+	layoutWork := !slices.Contains(userSheets, workSheet)
+	lastWorkRow, err := writeWorkSheet(f, config, workSheet, series, annotations, layoutWork, hyperlinkStyleID, percentStyleID, dateStyleID, numStyleID)
+	if err != nil {
+		return errors.WithStack(err)
 	}
 
-	// Add issues data
-	for i := range issues {
-		issue := &issues[i]
-		rowNum := i + 2
+	// Create Projections sheet
+	projectionsSheet := "Projections"
+	if _, err := f.NewSheet(projectionsSheet); err != nil {
+		return errors.WithStack(err)
+	}
 
-		// The work ticket id.
-		idCell := fmt.Sprintf("A%d", rowNum)
-		if err := f.SetCellValue(workSheet, idCell, issue.Key); err != nil {
-			return errors.WithStack(err)
-		}
-		if err := f.SetCellHyperLink(workSheet, idCell, config.TicketUrl(issue.Key), "External", excelize.HyperlinkOpts{
-			Display: &issue.Key,
-		}); err != nil {
-			return errors.WithStack(err)
-		}
-		if err := f.SetCellStyle(workSheet, idCell, idCell, hyperlinkStyleID); err != nil {
+	// Headers for projections sheet
+	projectionHeaders := []struct {
+		col    string
+		header string
+	}{
+		{_COL_DATE, "Date"},
+		{_COL_COMPLETED, unitHeader(config, "Completed")},
+		{_COL_REMAINING, unitHeader(config, "Remaining")},
+		{_COL_SCOPE, unitHeader(config, "Scope")},
+		{_COL_ADDED, unitHeader(config, "Added")},
+		{_COL_REMOVED, unitHeader(config, "Removed")},
+		{_COL_REESTIMATED, unitHeader(config, "Re-estimated")},
+		{_COL_VELOCITY, unitHeader(config, "Velocity")},
+		{_COL_AVG_VELOCITY, fmt.Sprintf("Avg (%d%s)", movingAvgPeriods, timeline.Unit)},
+		{_COL_STD_VELOCITY, fmt.Sprintf("StdDev (%d%s)", movingAvgPeriods, timeline.Unit)},
+		{_COL_FAST, "Fast (p68)"},
+		{_COL_MEAN, "Mean"},
+		{_COL_SLOW, "Slow (p68)"},
+		{_COL_FAST_VELOCITY, "V. Fast (p68)"},
+		{_COL_SLOW_VELOCITY, "V. Slow (p68)"},
+	}
+	for _, h := range projectionHeaders {
+		if err := f.SetCellValue(projectionsSheet, h.col+"1", h.header); err != nil {
 			return errors.WithStack(err)
 		}
+	}
 
-		// Other work ticket details.
-		if err := f.SetCellValue(workSheet, fmt.Sprintf("B%d", rowNum), issue.Fields.Summary); err != nil {
-			return errors.WithStack(err)
-		}
-		if err := f.SetCellValue(workSheet, fmt.Sprintf("C%d", rowNum), issue.GetType()); err != nil {
-			return errors.WithStack(err)
+	// Workdays per period, for converting remaining periods into a projected date.
+	workdaysPerPeriod := strconv.FormatFloat(timeline.WorkdaysPerPeriod, 'f', -1, 64)
+
+	// Add projection data - one row per period
+	for periodIndex, period := range periods {
+		rowNum := periodIndex + 2
+		cellOf := func(col string) string {
+			return fmt.Sprintf("%s%d", col, rowNum)
 		}
-		if err := f.SetCellValue(workSheet, fmt.Sprintf("D%d", rowNum), issue.GetStatus()); err != nil {
+		point := series.Points[periodIndex]
+
+		// Set the date, as text in the form the Work sheet's period headers use, so formulas can look them up
+		// without formatting it, which would depend on the locale.
+		dateCell := cellOf(_COL_DATE)
+		if err := f.SetCellValue(projectionsSheet, dateCell, period.End.Format("2006-01-02")); err != nil {
 			return errors.WithStack(err)
 		}
-		if err := f.SetCellValue(workSheet, fmt.Sprintf("E%d", rowNum), issue.Fields.Assignee.DisplayName); err != nil {
+
+		// The work completed.
+		completedCell := cellOf(_COL_COMPLETED)
+		completedFormula := fmt.Sprintf(`=SUM(INDEX(Work!$2:$%d, , MATCH("EV "&%s, Work!$1:$1, 0)))`, lastWorkRow, dateCell)
+		if err := setFormula(f, config, projectionsSheet, completedCell, completedFormula, projectionValue(&series, periodIndex, _COL_COMPLETED)); err != nil {
 			return errors.WithStack(err)
 		}
-		if err := f.SetCellValue(workSheet, fmt.Sprintf("F%d", rowNum), issue.GetSize(config)); err != nil {
+		if err := f.SetCellStyle(projectionsSheet, completedCell, completedCell, numStyleID); err != nil {
 			return errors.WithStack(err)
 		}
 
-		// Weekly data - loop over reversedWeeks to match header order
-		col := 7 // Start after Size column (F)
-		for _, weekDate := range reversedWeeks {
-			// Get percent complete for this issue at this week date
-			percentComplete, err := issue.PercentCompleteOnDate(config, weekDate)
-			if err != nil {
+		// The changes in scope since the previous period (see the Scope sheet for the issues).
+		addedCell := cellOf(_COL_ADDED)
+		removedCell := cellOf(_COL_REMOVED)
+		reestimatedCell := cellOf(_COL_REESTIMATED)
+		if periodIndex > 0 {
+			if err := f.SetCellValue(projectionsSheet, addedCell, point.Added); err != nil {
 				return errors.WithStack(err)
 			}
-
-			// Set percent complete value (as fraction for Excel)
-			// Leave field blank is percent complete is zero.
-			percentCell := fmt.Sprintf("%s%d", string(rune('A'+col-1)), rowNum)
-			if percentComplete > 0 {
-				if err := f.SetCellValue(workSheet, percentCell, percentComplete); err != nil { // 0.0-1.0
-					return errors.WithStack(err)
-				}
-			}
-			if err := f.SetCellStyle(workSheet, percentCell, percentCell, percentStyleID); err != nil {
+			if err := f.SetCellValue(projectionsSheet, removedCell, point.Removed); err != nil {
 				return errors.WithStack(err)
 			}
-
-			// Earned Value formula: percent * size, blank if percent is zero for easy display.
-			earnedCell := fmt.Sprintf("%s%d", string(rune('A'+col)), rowNum)
-			// Find the value in the row that is under the Size column and then multiply that by percent complete.
-			earnedFormula := fmt.Sprintf(`=IF(%s=0, "", %s * HLOOKUP("Size", 1:%d, %d, 0))`, percentCell, percentCell, rowNum, rowNum)
-			if err := f.SetCellFormula(workSheet, earnedCell, earnedFormula); err != nil {
+			if err := f.SetCellValue(projectionsSheet, reestimatedCell, point.Reestimated); err != nil {
 				return errors.WithStack(err)
 			}
-			if err := f.SetCellStyle(workSheet, earnedCell, earnedCell, numStyleID); err != nil {
+			if err := f.SetCellStyle(projectionsSheet, addedCell, reestimatedCell, numStyleID); err != nil {
 				return errors.WithStack(err)
 			}
-
-			col += 2
-		}
-	}
-
-	// Create Projections sheet
-	projectionsSheet := "Projections"
-	if _, err := f.NewSheet(projectionsSheet); err != nil {
-		return errors.WithStack(err)
-	}
-
-	// Headers for projections sheet
-	if err := f.SetCellValue(projectionsSheet, "A1", "Date"); err != nil {
-		return errors.WithStack(err)
-	}
-	if err := f.SetCellValue(projectionsSheet, "B1", "Completed"); err != nil {
-		return errors.WithStack(err)
-	}
-	if err := f.SetCellValue(projectionsSheet, "C1", "Remaining"); err != nil {
-		return errors.WithStack(err)
-	}
-	if err := f.SetCellValue(projectionsSheet, "D1", "Velocity"); err != nil {
-		return errors.WithStack(err)
-	}
-	if err := f.SetCellValue(projectionsSheet, "E1", fmt.Sprintf("Avg (%dw)", movingAvgWeeks)); err != nil {
-		return errors.WithStack(err)
-	}
-	if err := f.SetCellValue(projectionsSheet, "F1", fmt.Sprintf("StdDev (%dw)", movingAvgWeeks)); err != nil {
-		return errors.WithStack(err)
-	}
-	if err := f.SetCellValue(projectionsSheet, "G1", "Fast (p68)"); err != nil {
-		return errors.WithStack(err)
-	}
-	if err := f.SetCellValue(projectionsSheet, "H1", "Mean"); err != nil {
-		return errors.WithStack(err)
-	}
-	if err := f.SetCellValue(projectionsSheet, "I1", "Slow (p68)"); err != nil {
-		return errors.WithStack(err)
-	}
-	if err := f.SetCellValue(projectionsSheet, "J1", "V. Fast (p68)"); err != nil {
-		return errors.WithStack(err)
-	}
-	if err := f.SetCellValue(projectionsSheet, "K1", "V. Slow (p68)"); err != nil {
-		return errors.WithStack(err)
-	}
-
-	// Add projection data - one row per week
-	for weekIndex, weekDate := range weeks {
-		rowNum := weekIndex + 2
-
-		// Set the date
-		dateCell := fmt.Sprintf("A%d", rowNum)
-		if err := f.SetCellValue(projectionsSheet, dateCell, weekDate.Format("2006-01-02")); err != nil {
-			return errors.WithStack(err)
 		}
 
-		// The work completed.
-		completedCell := fmt.Sprintf("B%d", rowNum)
-		completedFormula := fmt.Sprintf(`=SUM(INDEX(Work!$2:$10000, , MATCH("EV "&TEXT(%s,"mm-dd"), Work!$1:$1, 0)))`, dateCell)
-		if err := f.SetCellFormula(projectionsSheet, completedCell, completedFormula); err != nil {
-			return errors.WithStack(err)
+		// The total scope: the scope at the start, and then the previous scope adjusted by the changes.
+		scopeCell := cellOf(_COL_SCOPE)
+		if periodIndex == 0 {
+			if err := f.SetCellValue(projectionsSheet, scopeCell, point.Scope); err != nil {
+				return errors.WithStack(err)
+			}
+		} else {
+			priorScopeCell := fmt.Sprintf("%s%d", _COL_SCOPE, rowNum-1)
+			scopeFormula := fmt.Sprintf(`=%s+%s-%s+%s`, priorScopeCell, addedCell, removedCell, reestimatedCell)
+			if err := setFormula(f, config, projectionsSheet, scopeCell, scopeFormula, projectionValue(&series, periodIndex, _COL_SCOPE)); err != nil {
+				return errors.WithStack(err)
+			}
 		}
-		if err := f.SetCellStyle(projectionsSheet, completedCell, completedCell, numStyleID); err != nil {
+		if err := f.SetCellStyle(projectionsSheet, scopeCell, scopeCell, numStyleID); err != nil {
 			return errors.WithStack(err)
 		}
 
 		// The remaining work.
-		remainingCell := fmt.Sprintf("C%d", rowNum)
-		remainingFormula := fmt.Sprintf(`=SUM(INDEX(Work!$2:$10000, , MATCH("Size", Work!$1:$1, 0)))-%s`, completedCell)
-		if err := f.SetCellFormula(projectionsSheet, remainingCell, remainingFormula); err != nil {
+		remainingCell := cellOf(_COL_REMAINING)
+		remainingFormula := fmt.Sprintf(`=%s-%s`, scopeCell, completedCell)
+		if err := setFormula(f, config, projectionsSheet, remainingCell, remainingFormula, projectionValue(&series, periodIndex, _COL_REMAINING)); err != nil {
 			return errors.WithStack(err)
 		}
 		if err := f.SetCellStyle(projectionsSheet, remainingCell, remainingCell, numStyleID); err != nil {
@@ -240,13 +214,13 @@ func GenerateExcelReport(config *config.Config, issues []jira.Issue) error {
 		}
 
 		// We can only compute velocity if we're not the first data cell (need two data entries.)
-		velocityCell := fmt.Sprintf("D%d", rowNum)
-		firstVelocityCell := "D$3" // The cell where the first velocity is found.
-		if weekIndex > 0 {
+		velocityCell := cellOf(_COL_VELOCITY)
+		firstVelocityCell := _COL_VELOCITY + "$3" // The cell where the first velocity is found.
+		if periodIndex > 0 {
 			// The velocity computation.
-			priorCompletedCell := fmt.Sprintf("B%d", rowNum-1)
+			priorCompletedCell := fmt.Sprintf("%s%d", _COL_COMPLETED, rowNum-1)
 			velocityFormula := fmt.Sprintf(`=%s-%s`, completedCell, priorCompletedCell)
-			if err := f.SetCellFormula(projectionsSheet, velocityCell, velocityFormula); err != nil {
+			if err := setFormula(f, config, projectionsSheet, velocityCell, velocityFormula, projectionValue(&series, periodIndex, _COL_VELOCITY)); err != nil {
 				return errors.WithStack(err)
 			}
 			if err := f.SetCellStyle(projectionsSheet, velocityCell, velocityCell, numStyleID); err != nil {
@@ -256,11 +230,11 @@ func GenerateExcelReport(config *config.Config, issues []jira.Issue) error {
 
 		// Moving average velocity computation.
 		// We need at least two velocities.
-		avgVelocityCell := fmt.Sprintf("E%d", rowNum)
-		if weekIndex > 1 {
+		avgVelocityCell := cellOf(_COL_AVG_VELOCITY)
+		if periodIndex > 1 {
 			// The average velocity computation.
-			avgVelocityFormula := fmt.Sprintf(`=AVERAGE(OFFSET(%s, -1 * (MIN(COUNT(%s:%s),%d) -1), 0, MIN(COUNT(%s:%s),%d), 1))`, velocityCell, firstVelocityCell, velocityCell, movingAvgWeeks, firstVelocityCell, velocityCell, movingAvgWeeks)
-			if err := f.SetCellFormula(projectionsSheet, avgVelocityCell, avgVelocityFormula); err != nil {
+			avgVelocityFormula := fmt.Sprintf(`=AVERAGE(OFFSET(%s, -1 * (MIN(COUNT(%s:%s),%d) -1), 0, MIN(COUNT(%s:%s),%d), 1))`, velocityCell, firstVelocityCell, velocityCell, movingAvgPeriods, firstVelocityCell, velocityCell, movingAvgPeriods)
+			if err := setFormula(f, config, projectionsSheet, avgVelocityCell, avgVelocityFormula, projectionValue(&series, periodIndex, _COL_AVG_VELOCITY)); err != nil {
 				return errors.WithStack(err)
 			}
 			if err := f.SetCellStyle(projectionsSheet, avgVelocityCell, avgVelocityCell, numStyleID); err != nil {
@@ -269,11 +243,11 @@ func GenerateExcelReport(config *config.Config, issues []jira.Issue) error {
 		}
 
 		// All other computations require at least two average velocities.
-		if weekIndex > 2 {
+		if periodIndex > 2 {
 			// Standard deviation (of velocities).
-			stdVelocityCell := fmt.Sprintf("F%d", rowNum)
-			stdVelocityFormula := fmt.Sprintf(`=STDEV(OFFSET(%s, -1 * (MIN(COUNT(%s:%s),%d) -1), 0, MIN(COUNT(%s:%s),%d), 1))`, velocityCell, firstVelocityCell, velocityCell, movingAvgWeeks, firstVelocityCell, velocityCell, movingAvgWeeks)
-			if err := f.SetCellFormula(projectionsSheet, stdVelocityCell, stdVelocityFormula); err != nil {
+			stdVelocityCell := cellOf(_COL_STD_VELOCITY)
+			stdVelocityFormula := fmt.Sprintf(`=STDEV(OFFSET(%s, -1 * (MIN(COUNT(%s:%s),%d) -1), 0, MIN(COUNT(%s:%s),%d), 1))`, velocityCell, firstVelocityCell, velocityCell, movingAvgPeriods, firstVelocityCell, velocityCell, movingAvgPeriods)
+			if err := setFormula(f, config, projectionsSheet, stdVelocityCell, stdVelocityFormula, projectionValue(&series, periodIndex, _COL_STD_VELOCITY)); err != nil {
 				return errors.WithStack(err)
 			}
 			if err := f.SetCellStyle(projectionsSheet, stdVelocityCell, stdVelocityCell, numStyleID); err != nil {
@@ -281,13 +255,13 @@ func GenerateExcelReport(config *config.Config, issues []jira.Issue) error {
 			}
 
 			// What are the p68 velocity cell names.
-			fastVelocityCell := fmt.Sprintf("J%d", rowNum)
-			slowVelocityCell := fmt.Sprintf("K%d", rowNum)
+			fastVelocityCell := cellOf(_COL_FAST_VELOCITY)
+			slowVelocityCell := cellOf(_COL_SLOW_VELOCITY)
 
 			// Fast projection.
-			fastProjectionCell := fmt.Sprintf("G%d", rowNum)
-			fastProjectionFormula := fmt.Sprintf(`=WORKDAY(%s, CEILING((%s/%s)*5, 1))`, dateCell, remainingCell, fastVelocityCell)
-			if err := f.SetCellFormula(projectionsSheet, fastProjectionCell, fastProjectionFormula); err != nil {
+			fastProjectionCell := cellOf(_COL_FAST)
+			fastProjectionFormula := fmt.Sprintf(`=WORKDAY(%s, CEILING((%s/%s)*%s, 1))`, dateCell, remainingCell, fastVelocityCell, workdaysPerPeriod)
+			if err := setFormula(f, config, projectionsSheet, fastProjectionCell, fastProjectionFormula, projectionValue(&series, periodIndex, _COL_FAST)); err != nil {
 				return errors.WithStack(err)
 			}
 			if err := f.SetCellStyle(projectionsSheet, fastProjectionCell, fastProjectionCell, dateStyleID); err != nil {
@@ -295,9 +269,9 @@ func GenerateExcelReport(config *config.Config, issues []jira.Issue) error {
 			}
 
 			// Mean projection.
-			meanProjectionCell := fmt.Sprintf("H%d", rowNum)
-			meanProjectionFormula := fmt.Sprintf(`=WORKDAY(%s, CEILING((%s/%s)*5, 1))`, dateCell, remainingCell, avgVelocityCell)
-			if err := f.SetCellFormula(projectionsSheet, meanProjectionCell, meanProjectionFormula); err != nil {
+			meanProjectionCell := cellOf(_COL_MEAN)
+			meanProjectionFormula := fmt.Sprintf(`=WORKDAY(%s, CEILING((%s/%s)*%s, 1))`, dateCell, remainingCell, avgVelocityCell, workdaysPerPeriod)
+			if err := setFormula(f, config, projectionsSheet, meanProjectionCell, meanProjectionFormula, projectionValue(&series, periodIndex, _COL_MEAN)); err != nil {
 				return errors.WithStack(err)
 			}
 			if err := f.SetCellStyle(projectionsSheet, meanProjectionCell, meanProjectionCell, dateStyleID); err != nil {
@@ -305,9 +279,9 @@ func GenerateExcelReport(config *config.Config, issues []jira.Issue) error {
 			}
 
 			// Slow projection).
-			slowProjectionCell := fmt.Sprintf("I%d", rowNum)
-			slowProjectionFormula := fmt.Sprintf(`=WORKDAY(%s, CEILING((%s/%s)*5, 1))`, dateCell, remainingCell, slowVelocityCell)
-			if err := f.SetCellFormula(projectionsSheet, slowProjectionCell, slowProjectionFormula); err != nil {
+			slowProjectionCell := cellOf(_COL_SLOW)
+			slowProjectionFormula := fmt.Sprintf(`=WORKDAY(%s, CEILING((%s/%s)*%s, 1))`, dateCell, remainingCell, slowVelocityCell, workdaysPerPeriod)
+			if err := setFormula(f, config, projectionsSheet, slowProjectionCell, slowProjectionFormula, projectionValue(&series, periodIndex, _COL_SLOW)); err != nil {
 				return errors.WithStack(err)
 			}
 			if err := f.SetCellStyle(projectionsSheet, slowProjectionCell, slowProjectionCell, dateStyleID); err != nil {
@@ -316,7 +290,7 @@ func GenerateExcelReport(config *config.Config, issues []jira.Issue) error {
 
 			// Fast velocity (p68).
 			fastVelocityFormula := fmt.Sprintf(`=%s+(1*%s)`, avgVelocityCell, stdVelocityCell)
-			if err := f.SetCellFormula(projectionsSheet, fastVelocityCell, fastVelocityFormula); err != nil {
+			if err := setFormula(f, config, projectionsSheet, fastVelocityCell, fastVelocityFormula, projectionValue(&series, periodIndex, _COL_FAST_VELOCITY)); err != nil {
 				return errors.WithStack(err)
 			}
 			if err := f.SetCellStyle(projectionsSheet, fastVelocityCell, fastVelocityCell, numStyleID); err != nil {
@@ -325,7 +299,7 @@ func GenerateExcelReport(config *config.Config, issues []jira.Issue) error {
 
 			// Slow velocity (p68).
 			slowVelocityFormula := fmt.Sprintf(`=%s-(1*%s)`, avgVelocityCell, stdVelocityCell)
-			if err := f.SetCellFormula(projectionsSheet, slowVelocityCell, slowVelocityFormula); err != nil {
+			if err := setFormula(f, config, projectionsSheet, slowVelocityCell, slowVelocityFormula, projectionValue(&series, periodIndex, _COL_SLOW_VELOCITY)); err != nil {
 				return errors.WithStack(err)
 			}
 			if err := f.SetCellStyle(projectionsSheet, slowVelocityCell, slowVelocityCell, numStyleID); err != nil {
@@ -333,19 +307,100 @@ func GenerateExcelReport(config *config.Config, issues []jira.Issue) error {
 			}
 		}
 	}
+	if !slices.Contains(userSheets, projectionsSheet) {
+		if err := layoutProjectionsSheet(f, projectionsSheet, len(periods)+1); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	if err := writeHighlights(f, config, projectionsSheet, series, projectionsHighlightRules); err != nil {
+		return errors.WithStack(err)
+	}
+
+	// Headline figures for the latest period.
+	if err := writeSummarySheet(f, config, summarySheet, projectionsSheet, series, percentStyleID, dateStyleID, numStyleID); err != nil {
+		return errors.WithStack(err)
+	}
+
+	// The issues behind each change in scope.
+	if err := writeScopeSheet(f, config, series, hyperlinkStyleID, numStyleID); err != nil {
+		return errors.WithStack(err)
+	}
+
+	// The work in each workflow status per period.
+	flow, err := burndown.NewCumulativeFlow(config, issues, timeline)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if err := writeFlowSheet(f, config, flow, numStyleID); err != nil {
+		return errors.WithStack(err)
+	}
+
+	// Progress per assignee, team or component, if any breakdowns are configured.
+	if err := writeBreakdownSheets(f, config, series, numStyleID); err != nil {
+		return errors.WithStack(err)
+	}
+
+	// A burndown per group, if a group by field is configured.
+	if err := writeGroupSheets(f, config, issues, timeline, hyperlinkStyleID, dateStyleID, percentStyleID, numStyleID); err != nil {
+		return errors.WithStack(err)
+	}
 
-	// Remove the default sheet
-	if err := f.DeleteSheet("Sheet1"); err != nil {
+	// Burndown, burnup and velocity charts.
+	if err := writeChartsSheet(f, projectionsSheet, timeline); err != nil {
 		return errors.WithStack(err)
 	}
 
-	// Set active sheet to Issues sheet
-	f.SetActiveSheet(0)
+	// Sprint commitments, if periods are sprint-aligned.
+	if err := writeSprintsSheet(f, config, issues, projectionsSheet, series, dateStyleID, percentStyleID, numStyleID); err != nil {
+		return errors.WithStack(err)
+	}
+
+	// Target date feasibility, if any targets are configured.
+	if err := writeTargetsSheet(f, config, projectionsSheet, series, percentStyleID, numStyleID); err != nil {
+		return errors.WithStack(err)
+	}
+
+	// Forecast accuracy, if backtesting is enabled.
+	if err := writeBacktestSheets(f, config, series, dateStyleID, numStyleID); err != nil {
+		return errors.WithStack(err)
+	}
+
+	// The latest figures, in any template cells named for them.
+	if err := writeTemplateAnchors(f, config, projectionsSheet, series); err != nil {
+		return errors.WithStack(err)
+	}
+
+	// So a later update knows which sheets to replace.
+	if err := recordGeneratedSheets(f, keptSheets); err != nil {
+		return errors.WithStack(err)
+	}
 
 	// Save file
 	if err := f.SaveAs(config.OutputFile); err != nil {
 		return errors.WithStack(err)
 	}
+	if firstCol, lastCol := olderPeriodCols(config, periods); layoutWork && firstCol <= lastCol {
+		if err := outlineColumns(config.OutputFile, workSheet, firstCol, lastCol); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	if err := retypeCachedValues(f, config.OutputFile); err != nil {
+		return errors.WithStack(err)
+	}
 
 	return nil
 }
+
+// periodKey identifies a period in the Work sheet headers by its end date. It includes the year,
+// so projects spanning more than a year don't repeat headers.
+func periodKey(period burndown.Period) string {
+	return period.End.Format("2006-01-02")
+}
+
+// unitHeader labels a size-derived column with its unit when sizing by count, so throughput reports aren't mistaken for points.
+func unitHeader(config *config.Config, header string) string {
+	if config.IsCountSizing() {
+		return fmt.Sprintf("%s (%s)", header, config.SizeUnit())
+	}
+	return header
+}
@@ -0,0 +1,104 @@
+package excel
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
+
+	"go-burndown/burndown"
+	"go-burndown/config"
+	"go-burndown/jira"
+)
+
+// testTimeline is three weekly periods ending on Fridays.
+var testTimeline = burndown.Timeline{Unit: "w", WorkdaysPerPeriod: 5, Periods: []burndown.Period{
+	{Start: time.Date(2024, 12, 28, 0, 0, 0, 0, time.UTC), End: time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC)},
+	{Start: time.Date(2025, 1, 4, 0, 0, 0, 0, time.UTC), End: time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)},
+	{Start: time.Date(2025, 1, 11, 0, 0, 0, 0, time.UTC), End: time.Date(2025, 1, 17, 0, 0, 0, 0, time.UTC)},
+}}
+
+// testConfig writes a workbook to the path.
+func testConfig(path string) *config.Config {
+	return &config.Config{
+		OutputFile:     path,
+		MovingAvgWeeks: 4,
+		Jira: config.JiraConfig{
+			JiraURL:              "https://example.atlassian.net",
+			SizeField:            "customfield_10016",
+			PercentCompleteField: "Percent Complete",
+			DoneStatuses:         []string{"Done"},
+		},
+	}
+}
+
+// testIssues are issues of size 4, created before the timeline and half done in its second period, in the order given.
+func testIssues(t *testing.T, keys ...string) []jira.Issue {
+	var issues []jira.Issue
+	for _, key := range keys {
+		issue, err := jira.ParseIssue([]byte(fmt.Sprintf(`{"key": %q, "fields": {"created": "2024-12-20T10:00:00.000+0000", "summary": "Login", "status": {"name": "In Progress"}, "customfield_10016": 4},
+			"changelog": {"histories": [{"created": "2025-01-08T10:00:00.000+0000", "items": [{"field": "Percent Complete", "toString": "0.5"}]}]}}`, key)))
+		require.NoError(t, err)
+		issues = append(issues, *issue)
+	}
+	return issues
+}
+
+func TestProjectionsMatchWorkPeriods(t *testing.T) {
+	// 30 weekly periods from October 2024 into April 2025, so the period columns run past Z and across a year.
+	timeline := burndown.Timeline{Unit: "w", WorkdaysPerPeriod: 5}
+	for periodIndex := range 30 {
+		end := time.Date(2024, 10, 4, 0, 0, 0, 0, time.UTC).AddDate(0, 0, 7*periodIndex)
+		timeline.Periods = append(timeline.Periods, burndown.Period{Start: end.AddDate(0, 0, -6), End: end})
+	}
+	// Cached, so the completed work each formula found can be checked against the column it should have matched.
+	formulas := config.FormulasCached
+	config := testConfig(filepath.Join(t.TempDir(), "burndown.xlsx"))
+	config.Formulas = formulas
+	require.NoError(t, GenerateExcelReport(config, testIssues(t, "A-1", "A-2"), timeline))
+
+	f, err := excelize.OpenFile(config.OutputFile)
+	require.NoError(t, err)
+	defer f.Close()
+	work, err := f.GetRows("Work", excelize.Options{RawCellValue: true})
+	require.NoError(t, err)
+
+	for periodIndex, period := range timeline.Periods {
+		row := periodIndex + 2
+		date, err := f.GetCellValue("Projections", fmt.Sprintf("%s%d", _COL_DATE, row))
+		require.NoError(t, err)
+		assert.Equal(t, period.End.Format("2006-01-02"), date)
+		formula, err := f.GetCellFormula("Projections", fmt.Sprintf("%s%d", _COL_COMPLETED, row))
+		require.NoError(t, err)
+		assert.Contains(t, formula, fmt.Sprintf(`MATCH("EV "&%s%d, Work!$1:$1, 0)`, _COL_DATE, row))
+
+		// The header MATCH finds is the period's own, once, and its column sums to the completed work.
+		assert.Equal(t, 1, countOf(work[0], "EV "+date), date)
+		col := slices.Index(work[0], "EV "+date)
+		require.GreaterOrEqual(t, col, 0, date)
+		var completed float64
+		for _, issue := range work[1:3] {
+			if col < len(issue) && issue[col] != "" {
+				value, err := strconv.ParseFloat(issue[col], 64)
+				require.NoError(t, err)
+				completed += value
+			}
+		}
+		raw, err := f.GetCellValue("Projections", fmt.Sprintf("%s%d", _COL_COMPLETED, row), excelize.Options{RawCellValue: true})
+		require.NoError(t, err)
+		cached, err := strconv.ParseFloat(raw, 64)
+		require.NoError(t, err)
+		assert.Equal(t, cached, completed, date)
+	}
+
+	// The oldest periods are past column Z.
+	oldest, err := excelize.ColumnNumberToName(slices.Index(work[0], "EV 2024-10-04") + 1)
+	require.NoError(t, err)
+	assert.Greater(t, len(oldest), 1, oldest)
+}
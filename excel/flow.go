@@ -0,0 +1,99 @@
+package excel
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/xuri/excelize/v2"
+
+	"go-burndown/burndown"
+	"go-burndown/config"
+)
+
+// writeFlowSheet adds a sheet with the work in each workflow status per period, one column per status in workflow order,
+// and a stacked area chart of it with the last status (usually done) at the bottom.
+func writeFlowSheet(f *excelize.File, config *config.Config, flow burndown.CumulativeFlow, numStyleID int) error {
+	if len(flow.Points) == 0 || len(flow.Statuses) == 0 {
+		return nil
+	}
+
+	flowSheet := "Flow"
+	if _, err := f.NewSheet(flowSheet); err != nil {
+		return errors.WithStack(err)
+	}
+
+	headers := []string{"Date"}
+	for _, status := range flow.Statuses {
+		if config.CumulativeFlow.IsSizeMeasure() {
+			headers = append(headers, unitHeader(config, status))
+		} else {
+			headers = append(headers, status)
+		}
+	}
+	for i, header := range headers {
+		cell, err := excelize.CoordinatesToCellName(i+1, 1)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		if err := f.SetCellValue(flowSheet, cell, header); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+
+	for periodIndex, point := range flow.Points {
+		rowNum := periodIndex + 2
+
+		if err := f.SetCellValue(flowSheet, fmt.Sprintf("A%d", rowNum), point.Period.End.Format("2006-01-02")); err != nil {
+			return errors.WithStack(err)
+		}
+		for i, amount := range point.Amounts {
+			cell, err := excelize.CoordinatesToCellName(i+2, rowNum)
+			if err != nil {
+				return errors.WithStack(err)
+			}
+			if err := f.SetCellValue(flowSheet, cell, amount); err != nil {
+				return errors.WithStack(err)
+			}
+		}
+		if config.CumulativeFlow.IsSizeMeasure() {
+			lastCell, err := excelize.CoordinatesToCellName(len(point.Amounts)+1, rowNum)
+			if err != nil {
+				return errors.WithStack(err)
+			}
+			if err := f.SetCellStyle(flowSheet, fmt.Sprintf("B%d", rowNum), lastCell, numStyleID); err != nil {
+				return errors.WithStack(err)
+			}
+		}
+	}
+
+	// Stacked from the last status down, so work flows up into the bottom band.
+	lastRow := len(flow.Points) + 1
+	var series []excelize.ChartSeries
+	for i := len(flow.Statuses) - 1; i >= 0; i-- {
+		col, err := excelize.ColumnNumberToName(i + 2)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		series = append(series, excelize.ChartSeries{
+			Name:       fmt.Sprintf("%s!$%s$1", flowSheet, col),
+			Categories: fmt.Sprintf("%s!$A$2:$A$%d", flowSheet, lastRow),
+			Values:     fmt.Sprintf("%s!$%s$2:$%s$%d", flowSheet, col, col, lastRow),
+		})
+	}
+
+	chartCol, err := excelize.ColumnNumberToName(len(flow.Statuses) + 3)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if err := f.AddChart(flowSheet, chartCol+"2", &excelize.Chart{
+		Type:      excelize.AreaStacked,
+		Series:    series,
+		Title:     []excelize.RichTextRun{{Text: "Cumulative Flow"}},
+		Legend:    excelize.ChartLegend{Position: "bottom"},
+		Dimension: excelize.ChartDimension{Width: 960, Height: 360},
+	}); err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
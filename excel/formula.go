@@ -0,0 +1,248 @@
+package excel
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/xuri/excelize/v2"
+
+	"go-burndown/config"
+)
+
+// cachedTypes holds, for each workbook being written, the cells setFormula cached a number or a boolean in, by sheet
+// and cell, with the type the cell takes for its value. excelize types every formula cell as text, whatever its
+// cached value, so retypeCachedValues gives the cells their type back once the workbook is saved.
+var cachedTypes sync.Map // *excelize.File → map[string]map[string]string
+
+// setFormula writes a cell that computes its value, as configured: the formula alone, for the spreadsheet app to
+// compute, the formula with its value cached, or just the value. The value is what the burndown works the formula
+// out to, and nil where the formula gives a blank.
+func setFormula(f *excelize.File, config *config.Config, sheet, cell, formula string, value interface{}) error {
+	cached := value != nil && config.CachesValues()
+	if cached {
+		if err := f.SetCellValue(sheet, cell, value); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	if config.WritesFormulas() {
+		// Setting the formula keeps the cached value, but types it as text, so the cell is retyped once saved.
+		if err := f.SetCellFormula(sheet, cell, formula); err != nil {
+			return errors.WithStack(err)
+		}
+		if cached {
+			recordCachedType(f, sheet, cell, value)
+		}
+	}
+	return nil
+}
+
+// recordCachedType records the type of a formula cell's cached value, unless it is text, as the cell is typed already.
+// Dates are cached as numbers, as SetCellValue writes them.
+func recordCachedType(f *excelize.File, sheet, cell string, value interface{}) {
+	cellType := ""
+	switch value.(type) {
+	case string:
+		return
+	case bool:
+		cellType = "b"
+	}
+	sheets, _ := cachedTypes.LoadOrStore(f, map[string]map[string]string{})
+	cells := sheets.(map[string]map[string]string)
+	if cells[sheet] == nil {
+		cells[sheet] = map[string]string{}
+	}
+	cells[sheet][cell] = cellType
+}
+
+// retypeCachedValues gives the formula cells setFormula cached a number or a boolean in their type, in the workbook
+// saved at path. Only the start tags of those cells are rewritten, and the rest of the workbook is copied as is.
+func retypeCachedValues(f *excelize.File, path string) error {
+	sheets, ok := cachedTypes.LoadAndDelete(f)
+	if !ok {
+		return nil
+	}
+
+	r, err := zip.OpenReader(path)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer r.Close()
+
+	cellsByPath := map[string]map[string]string{}
+	for sheet, cells := range sheets.(map[string]map[string]string) {
+		sheetPath, err := sheetXMLPath(&r.Reader, sheet)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		cellsByPath[sheetPath] = cells
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	w := zip.NewWriter(tmp)
+	for _, file := range r.File {
+		cells, ok := cellsByPath[file.Name]
+		if !ok {
+			if err := w.Copy(file); err != nil {
+				return errors.WithStack(err)
+			}
+			continue
+		}
+		if err := retypeSheetCells(w, file, cells); err != nil {
+			return errors.Wrapf(err, "failed to retype the cached values of %s", file.Name)
+		}
+	}
+	if err := w.Close(); err != nil {
+		return errors.WithStack(err)
+	}
+	if err := tmp.Chmod(info.Mode()); err != nil {
+		return errors.WithStack(err)
+	}
+	if err := tmp.Close(); err != nil {
+		return errors.WithStack(err)
+	}
+	if err := r.Close(); err != nil {
+		return errors.WithStack(err)
+	}
+	return errors.WithStack(os.Rename(tmp.Name(), path))
+}
+
+// retypeSheetCells copies a sheet's XML, replacing the text type of the given cells with theirs, or dropping it for
+// numbers, which is the default type. The cells are found by reading the sheet token by token, and each start tag is
+// copied byte for byte unless it is one of them.
+func retypeSheetCells(w *zip.Writer, file *zip.File, cells map[string]string) error {
+	src, err := file.Open()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer src.Close()
+	data, err := io.ReadAll(src)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	dst, err := w.CreateHeader(&zip.FileHeader{Name: file.Name, Method: zip.Deflate, Modified: file.Modified})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	var copied int64
+	for {
+		start := decoder.InputOffset()
+		token, err := decoder.RawToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		element, ok := token.(xml.StartElement)
+		if !ok || element.Name.Local != "c" {
+			continue
+		}
+		cellType, ok := cells[cellRef(element)]
+		if !ok {
+			continue
+		}
+		end := decoder.InputOffset()
+		tag := string(data[start:end])
+		if cellType == "" {
+			tag = strings.Replace(tag, ` t="str"`, "", 1)
+		} else {
+			tag = strings.Replace(tag, ` t="str"`, ` t="`+cellType+`"`, 1)
+		}
+		if _, err := dst.Write(data[copied:start]); err != nil {
+			return errors.WithStack(err)
+		}
+		if _, err := io.WriteString(dst, tag); err != nil {
+			return errors.WithStack(err)
+		}
+		copied = end
+	}
+	_, err = dst.Write(data[copied:])
+	return errors.WithStack(err)
+}
+
+// cellRef is the reference of a cell element, such as B2.
+func cellRef(element xml.StartElement) string {
+	for _, attr := range element.Attr {
+		if attr.Name.Local == "r" {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+// sheetXMLPath finds the part of a saved workbook that holds a sheet, through the workbook's relationships.
+func sheetXMLPath(r *zip.Reader, sheet string) (string, error) {
+	var workbook struct {
+		Sheets []struct {
+			Name string `xml:"name,attr"`
+			RID  string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+		} `xml:"sheets>sheet"`
+	}
+	if err := readZipXML(r, "xl/workbook.xml", &workbook); err != nil {
+		return "", errors.WithStack(err)
+	}
+	var rels struct {
+		Relationships []struct {
+			ID     string `xml:"Id,attr"`
+			Target string `xml:"Target,attr"`
+		} `xml:"Relationship"`
+	}
+	if err := readZipXML(r, "xl/_rels/workbook.xml.rels", &rels); err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	for _, s := range workbook.Sheets {
+		if s.Name != sheet {
+			continue
+		}
+		for _, rel := range rels.Relationships {
+			if rel.ID != s.RID {
+				continue
+			}
+			if target, ok := strings.CutPrefix(rel.Target, "/"); ok {
+				return target, nil
+			}
+			return "xl/" + rel.Target, nil
+		}
+	}
+	return "", errors.Errorf("sheet %s not found", sheet)
+}
+
+// readZipXML decodes an XML part of a zip file.
+func readZipXML(r *zip.Reader, name string, v interface{}) error {
+	file, err := r.Open(name)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer file.Close()
+	return errors.WithStack(xml.NewDecoder(file).Decode(v))
+}
+
+// formulaCell is a streamed cell that computes its value. Streamed sheets are large, so their formulas always
+// come with their values cached, unless only values are written. The stream writer types the cell by its value.
+func formulaCell(config *config.Config, formula string, value interface{}, styleID int) excelize.Cell {
+	cell := excelize.Cell{StyleID: styleID, Value: value}
+	if config.WritesFormulas() {
+		cell.Formula = formula
+	}
+	return cell
+}
@@ -0,0 +1,61 @@
+package excel
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
+
+	"go-burndown/config"
+)
+
+func TestCachedFormulaTypes(t *testing.T) {
+	tests := []struct {
+		formulas string
+		// The Work sheet's issue key is a text formula with its cached key, or the key alone.
+		keyType excelize.CellType
+		formula bool
+	}{
+		{formulas: config.FormulasCached, keyType: excelize.CellTypeFormula, formula: true},
+		{formulas: config.FormulasValues, keyType: excelize.CellTypeInlineString},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.formulas, func(t *testing.T) {
+			config := testConfig(filepath.Join(t.TempDir(), "burndown.xlsx"))
+			config.Formulas = tt.formulas
+			require.NoError(t, GenerateExcelReport(config, testIssues(t, "A-1"), testTimeline))
+
+			f, err := excelize.OpenFile(config.OutputFile)
+			require.NoError(t, err)
+			defer f.Close()
+
+			// The latest period's figures are numbers, whether or not they come with their formulas: 2 of 4 completed.
+			for _, expected := range []struct {
+				sheet, cell string
+				cellType    excelize.CellType
+				raw         string
+			}{
+				{"Projections", _COL_COMPLETED + "4", excelize.CellTypeUnset, "2"},
+				{"Projections", _COL_REMAINING + "4", excelize.CellTypeUnset, "2"},
+				{"Work", "A2", tt.keyType, "A-1"},
+			} {
+				cellType, err := f.GetCellType(expected.sheet, expected.cell)
+				require.NoError(t, err)
+				assert.Equal(t, expected.cellType, cellType, expected.cell)
+				raw, err := f.GetCellValue(expected.sheet, expected.cell, excelize.Options{RawCellValue: true})
+				require.NoError(t, err)
+				assert.Equal(t, expected.raw, raw, expected.cell)
+			}
+
+			formula, err := f.GetCellFormula("Projections", _COL_COMPLETED+"4")
+			require.NoError(t, err)
+			assert.Equal(t, tt.formula, formula != "")
+			formula, err = f.GetCellFormula("Work", "A2")
+			require.NoError(t, err)
+			assert.Equal(t, tt.formula, formula != "")
+		})
+	}
+}
@@ -0,0 +1,251 @@
+package excel
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/xuri/excelize/v2"
+
+	"go-burndown/burndown"
+	"go-burndown/config"
+	"go-burndown/jira"
+)
+
+const (
+	// Excel limits sheet names to 31 characters.
+	//revive:disable:var-naming
+	_MAX_SHEET_NAME_LENGTH = 31
+)
+
+// writeGroupSheets adds a Projections-style sheet per group, if a group by field is configured, and a Groups sheet
+// comparing the groups at the latest period. Group sheets hold computed values, since the Work sheet isn't split by group.
+func writeGroupSheets(f *excelize.File, config *config.Config, issues []jira.Issue, timeline burndown.Timeline, hyperlinkStyleID, dateStyleID, percentStyleID, numStyleID int) error {
+	if config.GroupBy == "" || len(timeline.Periods) == 0 {
+		return nil
+	}
+
+	grouped, err := burndown.NewGroupedSeries(config, issues, timeline)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	groupsSheet := "Groups"
+	if _, err := f.NewSheet(groupsSheet); err != nil {
+		return errors.WithStack(err)
+	}
+
+	headers := []string{"Group", "Issues", unitHeader(config, "Scope"), unitHeader(config, "Completed"), unitHeader(config, "Remaining"), "Completed %",
+		fmt.Sprintf("Avg (%d%s)", config.MovingAvgWeeks, timeline.Unit), "Fast (p68)", "Mean", "Slow (p68)"}
+	if err := setHeaders(f, groupsSheet, headers); err != nil {
+		return errors.WithStack(err)
+	}
+
+	// Group sheets steer clear of every sheet already in the workbook, including the template's and kept ones.
+	usedSheetNames := map[string]bool{}
+	for _, sheet := range f.GetSheetList() {
+		usedSheetNames[strings.ToLower(sheet)] = true
+	}
+	for groupIndex, group := range grouped {
+		rowNum := groupIndex + 2
+		latestIndex := len(group.Series.Points) - 1
+		latest := group.Series.Points[latestIndex]
+
+		groupSheet := groupSheetName(group.Name, usedSheetNames)
+		if err := writeGroupSheet(f, config, groupSheet, group.Series, dateStyleID, numStyleID); err != nil {
+			return errors.WithStack(err)
+		}
+
+		// The group name links to its sheet.
+		nameCell := fmt.Sprintf("A%d", rowNum)
+		if err := f.SetCellValue(groupsSheet, nameCell, group.Name); err != nil {
+			return errors.WithStack(err)
+		}
+		if err := f.SetCellHyperLink(groupsSheet, nameCell, fmt.Sprintf("'%s'!A1", groupSheet), "Location"); err != nil {
+			return errors.WithStack(err)
+		}
+		if err := f.SetCellStyle(groupsSheet, nameCell, nameCell, hyperlinkStyleID); err != nil {
+			return errors.WithStack(err)
+		}
+
+		if err := f.SetCellValue(groupsSheet, fmt.Sprintf("B%d", rowNum), len(group.Series.Issues)); err != nil {
+			return errors.WithStack(err)
+		}
+		if err := f.SetCellValue(groupsSheet, fmt.Sprintf("C%d", rowNum), latest.Scope); err != nil {
+			return errors.WithStack(err)
+		}
+		if err := f.SetCellValue(groupsSheet, fmt.Sprintf("D%d", rowNum), latest.Completed); err != nil {
+			return errors.WithStack(err)
+		}
+		if err := f.SetCellValue(groupsSheet, fmt.Sprintf("E%d", rowNum), latest.Remaining); err != nil {
+			return errors.WithStack(err)
+		}
+		if err := f.SetCellStyle(groupsSheet, fmt.Sprintf("C%d", rowNum), fmt.Sprintf("E%d", rowNum), numStyleID); err != nil {
+			return errors.WithStack(err)
+		}
+
+		completedPercentCell := fmt.Sprintf("F%d", rowNum)
+		completedPercentFormula := fmt.Sprintf(`=IF(C%d=0, "", D%d/C%d)`, rowNum, rowNum, rowNum)
+		var completedPercent interface{}
+		if latest.Scope != 0 {
+			completedPercent = latest.Completed / latest.Scope
+		}
+		if err := setFormula(f, config, groupsSheet, completedPercentCell, completedPercentFormula, completedPercent); err != nil {
+			return errors.WithStack(err)
+		}
+		if err := f.SetCellStyle(groupsSheet, completedPercentCell, completedPercentCell, percentStyleID); err != nil {
+			return errors.WithStack(err)
+		}
+
+		if latest.HasAvg {
+			avgCell := fmt.Sprintf("G%d", rowNum)
+			if err := f.SetCellValue(groupsSheet, avgCell, latest.AvgVelocity); err != nil {
+				return errors.WithStack(err)
+			}
+			if err := f.SetCellStyle(groupsSheet, avgCell, avgCell, numStyleID); err != nil {
+				return errors.WithStack(err)
+			}
+		}
+
+		if forecast, ok := group.Series.Forecast(latestIndex); ok {
+			if err := setForecastCells(f, groupsSheet, rowNum, "H", forecast, dateStyleID); err != nil {
+				return errors.WithStack(err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// writeGroupSheet adds one group's sheet, mirroring the Projections sheet's columns with computed values.
+func writeGroupSheet(f *excelize.File, config *config.Config, groupSheet string, series burndown.Series, dateStyleID, numStyleID int) error {
+	if _, err := f.NewSheet(groupSheet); err != nil {
+		return errors.WithStack(err)
+	}
+
+	headers := []string{"Date", unitHeader(config, "Completed"), unitHeader(config, "Remaining"), unitHeader(config, "Scope"), unitHeader(config, "Velocity"),
+		fmt.Sprintf("Avg (%d%s)", config.MovingAvgWeeks, series.Timeline.Unit), fmt.Sprintf("StdDev (%d%s)", config.MovingAvgWeeks, series.Timeline.Unit),
+		"Fast (p68)", "Mean", "Slow (p68)"}
+	if err := setHeaders(f, groupSheet, headers); err != nil {
+		return errors.WithStack(err)
+	}
+
+	for periodIndex, point := range series.Points {
+		rowNum := periodIndex + 2
+
+		if err := f.SetCellValue(groupSheet, fmt.Sprintf("A%d", rowNum), point.Period.End.Format("2006-01-02")); err != nil {
+			return errors.WithStack(err)
+		}
+		if err := f.SetCellValue(groupSheet, fmt.Sprintf("B%d", rowNum), point.Completed); err != nil {
+			return errors.WithStack(err)
+		}
+		if err := f.SetCellValue(groupSheet, fmt.Sprintf("C%d", rowNum), point.Remaining); err != nil {
+			return errors.WithStack(err)
+		}
+		if err := f.SetCellValue(groupSheet, fmt.Sprintf("D%d", rowNum), point.Scope); err != nil {
+			return errors.WithStack(err)
+		}
+		if point.HasVelocity {
+			if err := f.SetCellValue(groupSheet, fmt.Sprintf("E%d", rowNum), point.Velocity); err != nil {
+				return errors.WithStack(err)
+			}
+		}
+		if point.HasAvg {
+			if err := f.SetCellValue(groupSheet, fmt.Sprintf("F%d", rowNum), point.AvgVelocity); err != nil {
+				return errors.WithStack(err)
+			}
+		}
+		if point.HasStdDev {
+			if err := f.SetCellValue(groupSheet, fmt.Sprintf("G%d", rowNum), point.StdDev); err != nil {
+				return errors.WithStack(err)
+			}
+		}
+		if err := f.SetCellStyle(groupSheet, fmt.Sprintf("B%d", rowNum), fmt.Sprintf("G%d", rowNum), numStyleID); err != nil {
+			return errors.WithStack(err)
+		}
+
+		if forecast, ok := series.Forecast(periodIndex); ok {
+			if err := setForecastCells(f, groupSheet, rowNum, "H", forecast, dateStyleID); err != nil {
+				return errors.WithStack(err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// setHeaders writes a header row across the first columns of a sheet.
+func setHeaders(f *excelize.File, sheet string, headers []string) error {
+	for i, header := range headers {
+		cell, err := excelize.CoordinatesToCellName(i+1, 1)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		if err := f.SetCellValue(sheet, cell, header); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	return nil
+}
+
+// setForecastCells writes the Fast, Mean and Slow dates into three columns starting at the given column,
+// leaving any date that can't be projected (no progress) blank.
+func setForecastCells(f *excelize.File, sheet string, rowNum int, firstCol string, forecast burndown.Forecast, dateStyleID int) error {
+	col, err := excelize.ColumnNameToNumber(firstCol)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	for i, date := range []time.Time{forecast.Fast, forecast.Mean, forecast.Slow} {
+		if date.IsZero() {
+			continue
+		}
+		cell, err := excelize.CoordinatesToCellName(col+i, rowNum)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		if err := f.SetCellValue(sheet, cell, date); err != nil {
+			return errors.WithStack(err)
+		}
+		if err := f.SetCellStyle(sheet, cell, cell, dateStyleID); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	return nil
+}
+
+// groupSheetName makes a unique, valid sheet name for a group: characters Excel forbids are replaced,
+// and long names are truncated, with a number added if the name is already taken.
+func groupSheetName(group string, used map[string]bool) string {
+	name := strings.NewReplacer(":", "-", "\\", "-", "/", "-", "?", "-", "*", "-", "[", "(", "]", ")", "'", "").Replace(group)
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name = "Group"
+	}
+	candidate := truncateRunes(name, _MAX_SHEET_NAME_LENGTH)
+	for n := 2; used[strings.ToLower(candidate)] || isReservedSheetName(candidate); n++ {
+		suffix := fmt.Sprintf(" (%d)", n)
+		candidate = truncateRunes(name, _MAX_SHEET_NAME_LENGTH-len(suffix)) + suffix
+	}
+	used[strings.ToLower(candidate)] = true
+	return candidate
+}
+
+// isReservedSheetName checks if a group's sheet would clash with one of the report's own sheets.
+func isReservedSheetName(name string) bool {
+	reserved := []string{"Summary", "Work", "Projections", "Scope", "Flow", "Charts", "Sprints", "Targets", "Backtest", "Accuracy", "Groups",
+		"By Assignee", "By Team", "By Component"}
+	return slices.ContainsFunc(reserved, func(sheet string) bool {
+		return strings.EqualFold(name, sheet)
+	})
+}
+
+// truncateRunes shortens a string to at most n characters.
+func truncateRunes(value string, n int) string {
+	runes := []rune(value)
+	if len(runes) <= n {
+		return value
+	}
+	return strings.TrimSpace(string(runes[:n]))
+}
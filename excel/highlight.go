@@ -0,0 +1,183 @@
+package excel
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/xuri/excelize/v2"
+
+	"go-burndown/burndown"
+	"go-burndown/config"
+)
+
+// highlightColors are the default fills of the highlight rules.
+var highlightColors = map[string]string{
+	config.HighlightUnestimated: "#fff2cc",
+	config.HighlightStalled:     "#fce4d6",
+	config.HighlightRegressed:   "#ffc7ce",
+	config.HighlightSlipping:    "#ffc7ce",
+}
+
+// highlightRule is conditional formatting that fills cells needing attention.
+type highlightRule struct {
+	name string
+	// cells picks the cells to fill, and the formula, relative to the first cell, that is true where they are filled.
+	// There are no cells where the rule doesn't apply.
+	cells func(config *config.Config, series burndown.Series) (rangeRef, formula string, err error)
+}
+
+// workHighlightRules highlight the details of the Work sheet's issues, most pressing first. The stalled and
+// unestimated rules are formulas on each row, so they follow edits to the sheet.
+var workHighlightRules = []highlightRule{
+	{config.HighlightRegressed, regressedCells},
+	{config.HighlightStalled, stalledCells},
+	{config.HighlightUnestimated, unestimatedCells},
+}
+
+// projectionsHighlightRules highlight the forecasts on the Projections sheet.
+var projectionsHighlightRules = []highlightRule{
+	{config.HighlightSlipping, slippingCells},
+}
+
+// writeHighlights adds the configured highlight rules to a sheet. Streamed sheets take them before they are flushed.
+func writeHighlights(f *excelize.File, config *config.Config, sheet string, series burndown.Series, rules []highlightRule) error {
+	for _, rule := range rules {
+		if !config.Highlights.Highlights(rule.name) {
+			continue
+		}
+		rangeRef, formula, err := rule.cells(config, series)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		if rangeRef == "" {
+			continue
+		}
+
+		color, ok := config.Highlights.Colors[rule.name]
+		if !ok {
+			color = highlightColors[rule.name]
+		}
+		styleID, err := f.NewConditionalStyle(&excelize.Style{Fill: excelize.Fill{Type: "pattern", Color: []string{color}, Pattern: 1}})
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		if err := f.SetConditionalFormat(sheet, rangeRef, []excelize.ConditionalFormatOptions{
+			{Type: "formula", Criteria: formula, Format: &styleID},
+		}); err != nil {
+			return errors.Wrapf(err, "failed to highlight %s", rule.name)
+		}
+	}
+	return nil
+}
+
+// regressedCells picks the issues that were reopened or had their percent complete lowered by the latest period.
+// Regressions don't show on the sheet, as progress never decreases, so their rows are picked out from the history.
+func regressedCells(config *config.Config, series burndown.Series) (string, string, error) {
+	periods := series.Timeline.Periods
+	if len(periods) == 0 {
+		return "", "", nil
+	}
+	date := periods[len(periods)-1].End
+
+	var rows []int
+	for i := range series.Issues {
+		regressed, err := series.Issues[i].Issue.IsRegressedOnDate(config, date)
+		if err != nil {
+			return "", "", errors.WithStack(err)
+		}
+		if regressed {
+			rows = append(rows, i+2)
+		}
+	}
+
+	var ranges []string
+	for _, run := range rowRuns(rows) {
+		ranges = append(ranges, workDetailRange(config, run[0], run[1]))
+	}
+	return strings.Join(ranges, " "), "TRUE", nil
+}
+
+// stalledCells picks the open issues with some progress, but no more than they had the configured number of periods
+// before.
+func stalledCells(config *config.Config, series burndown.Series) (string, string, error) {
+	stalledPeriods := int(config.Highlights.StalledPeriodsOrDefault())
+	if len(series.Issues) == 0 || len(series.Timeline.Periods) <= stalledPeriods {
+		return "", "", nil
+	}
+
+	firstPeriodCol := _WORK_FIRST_PERIOD_COL + len(config.Columns)
+	latestCol, err := excelize.ColumnNumberToName(firstPeriodCol)
+	if err != nil {
+		return "", "", errors.WithStack(err)
+	}
+	earlierCol, err := excelize.ColumnNumberToName(firstPeriodCol + 2*stalledPeriods)
+	if err != nil {
+		return "", "", errors.WithStack(err)
+	}
+	formula := fmt.Sprintf("AND(%s, N($%s2)>0, $%s2<1, $%s2=$%s2)", workOpenFormula(config), latestCol, latestCol, latestCol, earlierCol)
+	return workDetailRange(config, 2, len(series.Issues)+1), formula, nil
+}
+
+// unestimatedCells picks the open issues with no size, as Issue.IsUnestimated does. Every issue has a size when
+// sizing by count.
+func unestimatedCells(config *config.Config, series burndown.Series) (string, string, error) {
+	if len(series.Issues) == 0 || config.IsCountSizing() {
+		return "", "", nil
+	}
+	formula := fmt.Sprintf("AND(%s, N($%s2)=0)", workOpenFormula(config), _WORK_SIZE_COL)
+	return workDetailRange(config, 2, len(series.Issues)+1), formula, nil
+}
+
+// slippingCells picks the forecast dates past the target date. Forecast cells are blank until there is a forecast.
+func slippingCells(config *config.Config, series burndown.Series) (string, string, error) {
+	target := config.HighlightTarget()
+	if target == "" || len(series.Timeline.Periods) == 0 {
+		return "", "", nil
+	}
+	targetDate, err := time.Parse("2006-01-02", target)
+	if err != nil {
+		return "", "", errors.Wrapf(err, "invalid target date format: %s", target)
+	}
+
+	firstCell := _COL_FAST + "2"
+	formula := fmt.Sprintf("AND(ISNUMBER(%s), %s>DATE(%d,%d,%d))", firstCell, firstCell, targetDate.Year(), targetDate.Month(), targetDate.Day())
+	return fmt.Sprintf("%s:%s%d", firstCell, _COL_SLOW, len(series.Timeline.Periods)+1), formula, nil
+}
+
+// workDetailRange is the Work sheet's issue details, Issue Key to the configured columns, over a run of rows.
+func workDetailRange(config *config.Config, firstRow, lastRow int) string {
+	lastDetailCol, _ := excelize.ColumnNumberToName(_WORK_FIRST_PERIOD_COL - 1 + len(config.Columns))
+	return fmt.Sprintf("A%d:%s%d", firstRow, lastDetailCol, lastRow)
+}
+
+// workOpenFormula checks that the issue in the first row of the Work sheet is open: in neither a done nor a removed status.
+func workOpenFormula(config *config.Config) string {
+	var closed []string
+	for _, status := range append(append([]string{}, config.Jira.DoneStatuses...), config.Jira.RemovedStatuses...) {
+		closed = append(closed, fmt.Sprintf("$%s2=%s", _WORK_STATUS_COL, excelString(status)))
+	}
+	if len(closed) == 0 {
+		return "TRUE"
+	}
+	return fmt.Sprintf("NOT(OR(%s))", strings.Join(closed, ", "))
+}
+
+// rowRuns collapses ascending row numbers into runs of consecutive rows, each its first and last row.
+func rowRuns(rows []int) [][2]int {
+	var runs [][2]int
+	for _, row := range rows {
+		if len(runs) > 0 && runs[len(runs)-1][1] == row-1 {
+			runs[len(runs)-1][1] = row
+			continue
+		}
+		runs = append(runs, [2]int{row, row})
+	}
+	return runs
+}
+
+// excelString quotes text as an Excel formula string.
+func excelString(text string) string {
+	return `"` + strings.ReplaceAll(text, `"`, `""`) + `"`
+}
@@ -0,0 +1,108 @@
+package excel
+
+import (
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+	"github.com/xuri/excelize/v2"
+)
+
+const (
+	// Column widths in characters, for sizing columns to their contents.
+	//revive:disable:var-naming
+	_MIN_COL_WIDTH = 8
+	_MAX_COL_WIDTH = 50
+	// The built-in Excel table style of the sheets written as tables.
+	_TABLE_STYLE = "TableStyleLight9"
+)
+
+// fitWidth widens a column's width to fit the text, as Excel's AutoFit would.
+func fitWidth(width int, text string) int {
+	return max(width, utf8.RuneCountInString(text)+2)
+}
+
+// colWidth limits a fitted width, so long summaries don't push the columns after them out of view.
+func colWidth(width int) float64 {
+	return float64(min(max(width, _MIN_COL_WIDTH), _MAX_COL_WIDTH))
+}
+
+// cellText is roughly how a value shows in a cell, for sizing its column.
+func cellText(value interface{}) string {
+	switch value := value.(type) {
+	case string:
+		return value
+	case float64:
+		return strconv.FormatFloat(value, 'f', 1, 64)
+	case int:
+		return strconv.Itoa(value)
+	case time.Time:
+		return value.Format("2006-01-02")
+	case excelize.Cell:
+		return cellText(value.Value)
+	}
+	return ""
+}
+
+// frozenPanes freezes the header row and the columns left of the cell, so they stay in view while scrolling.
+func frozenPanes(topLeftCell string) (*excelize.Panes, error) {
+	col, _, err := excelize.CellNameToCoordinates(topLeftCell)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return &excelize.Panes{
+		Freeze:      true,
+		XSplit:      col - 1,
+		YSplit:      1,
+		TopLeftCell: topLeftCell,
+		ActivePane:  "bottomRight",
+		Selection:   []excelize.Selection{{SQRef: topLeftCell, ActiveCell: topLeftCell, Pane: "bottomRight"}},
+	}, nil
+}
+
+// sheetTable is an Excel table over a sheet's rows, with a filter on every header.
+func sheetTable(name string, lastCol, lastRow int) (*excelize.Table, error) {
+	lastCell, err := excelize.CoordinatesToCellName(lastCol, max(lastRow, 2))
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return &excelize.Table{Range: "A1:" + lastCell, Name: name, StyleName: _TABLE_STYLE}, nil
+}
+
+// hasDuplicates checks if any header repeats.
+func hasDuplicates(headers []string) bool {
+	seen := make(map[string]bool, len(headers))
+	for _, header := range headers {
+		if seen[header] {
+			return true
+		}
+		seen[header] = true
+	}
+	return false
+}
+
+// outlineColumns groups a sheet's columns in a saved workbook, so they can be collapsed. The stream writer can't
+// write outline levels, so a streamed sheet is grouped once the workbook is saved, by opening it again.
+func outlineColumns(path, sheet string, firstCol, lastCol int) error {
+	f, err := excelize.OpenFile(path)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer f.Close()
+
+	for col := firstCol; col <= lastCol; col++ {
+		name, err := excelize.ColumnNumberToName(col)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		if err := f.SetColOutlineLevel(sheet, name, 1); err != nil {
+			return errors.Wrapf(err, "failed to group the columns of %s", sheet)
+		}
+	}
+	return errors.WithStack(f.Save())
+}
+
+func boolPtr(value bool) *bool {
+	return &value
+}
@@ -0,0 +1,32 @@
+package excel
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
+)
+
+func TestOlderPeriodColumnsOutlined(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "burndown.xlsx")
+	require.NoError(t, GenerateExcelReport(testConfig(path), testIssues(t, "A-1"), testTimeline))
+
+	f, err := excelize.OpenFile(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	// The latest period's % and EV columns stay out of the outline; the two older periods' columns are in it.
+	levels := map[string]uint8{}
+	for _, col := range []string{"F", "G", "H", "I", "J", "K", "L"} {
+		level, err := f.GetColOutlineLevel("Work", col)
+		require.NoError(t, err)
+		levels[col] = level
+	}
+	assert.Equal(t, map[string]uint8{"F": 0, "G": 0, "H": 0, "I": 1, "J": 1, "K": 1, "L": 1}, levels)
+
+	header, err := f.GetCellValue("Work", "I1")
+	require.NoError(t, err)
+	assert.Equal(t, "% 2025-01-10", header)
+}
@@ -0,0 +1,109 @@
+package excel
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/xuri/excelize/v2"
+
+	"go-burndown/burndown"
+)
+
+// Projections sheet columns, shared with the sheets and charts that reference it.
+const (
+	//revive:disable:var-naming
+	_COL_DATE          = "A"
+	_COL_COMPLETED     = "B"
+	_COL_REMAINING     = "C"
+	_COL_SCOPE         = "D"
+	_COL_ADDED         = "E"
+	_COL_REMOVED       = "F"
+	_COL_REESTIMATED   = "G"
+	_COL_VELOCITY      = "H"
+	_COL_AVG_VELOCITY  = "I"
+	_COL_STD_VELOCITY  = "J"
+	_COL_FAST          = "K"
+	_COL_MEAN          = "L"
+	_COL_SLOW          = "M"
+	_COL_FAST_VELOCITY = "N"
+	_COL_SLOW_VELOCITY = "O"
+)
+
+// projectionValue is the value a Projections cell computes to, as worked out by the burndown, or nil where the cell is blank.
+func projectionValue(series *burndown.Series, periodIndex int, col string) interface{} {
+	point := series.Points[periodIndex]
+	forecastDate := func(date func(burndown.Forecast) time.Time) interface{} {
+		forecast, ok := series.Forecast(periodIndex)
+		if !ok || date(forecast).IsZero() {
+			return nil
+		}
+		return date(forecast)
+	}
+
+	switch col {
+	case _COL_DATE:
+		return point.Period.End
+	case _COL_COMPLETED:
+		return point.Completed
+	case _COL_REMAINING:
+		return point.Remaining
+	case _COL_SCOPE:
+		return point.Scope
+	case _COL_VELOCITY:
+		if point.HasVelocity {
+			return point.Velocity
+		}
+	case _COL_AVG_VELOCITY:
+		if point.HasAvg {
+			return point.AvgVelocity
+		}
+	case _COL_STD_VELOCITY:
+		if point.HasStdDev {
+			return point.StdDev
+		}
+	case _COL_FAST:
+		return forecastDate(func(forecast burndown.Forecast) time.Time { return forecast.Fast })
+	case _COL_MEAN:
+		return forecastDate(func(forecast burndown.Forecast) time.Time { return forecast.Mean })
+	case _COL_SLOW:
+		return forecastDate(func(forecast burndown.Forecast) time.Time { return forecast.Slow })
+	case _COL_FAST_VELOCITY:
+		if point.HasStdDev {
+			return point.AvgVelocity + point.StdDev
+		}
+	case _COL_SLOW_VELOCITY:
+		if point.HasStdDev {
+			return point.AvgVelocity - point.StdDev
+		}
+	}
+	return nil
+}
+
+// layoutProjectionsSheet lays the Projections sheet out as a table, with the header row and dates kept in view.
+func layoutProjectionsSheet(f *excelize.File, projectionsSheet string, lastRow int) error {
+	panes, err := frozenPanes("B2")
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if err := f.SetPanes(projectionsSheet, panes); err != nil {
+		return errors.WithStack(err)
+	}
+
+	// Wide enough for the headers and for dates.
+	if err := f.SetColWidth(projectionsSheet, _COL_DATE, _COL_SLOW_VELOCITY, 14); err != nil {
+		return errors.WithStack(err)
+	}
+
+	lastCol, err := excelize.ColumnNameToNumber(_COL_SLOW_VELOCITY)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	table, err := sheetTable("ProjectionsTable", lastCol, lastRow)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if err := f.AddTable(projectionsSheet, table); err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package excel
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/xuri/excelize/v2"
+
+	"go-burndown/burndown"
+	"go-burndown/config"
+)
+
+// writeScopeSheet adds a sheet listing the issues behind each period's change in scope, one row per issue,
+// so the Added, Removed and Re-estimated columns of the Projections sheet can be traced back to tickets.
+func writeScopeSheet(f *excelize.File, config *config.Config, series burndown.Series, hyperlinkStyleID, numStyleID int) error {
+	scopeSheet := "Scope"
+	if _, err := f.NewSheet(scopeSheet); err != nil {
+		return errors.WithStack(err)
+	}
+
+	// Streamed, since every issue added or re-estimated over a long project gives a row.
+	sw, err := f.NewStreamWriter(scopeSheet)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	headers := []interface{}{"Date", "Change", "Issue Key", "Summary", unitHeader(config, "Size Before"), unitHeader(config, "Size After"), unitHeader(config, "Scope Change")}
+	if err := sw.SetRow("A1", headers); err != nil {
+		return errors.WithStack(err)
+	}
+
+	rowNum := 2
+	for _, point := range series.Points {
+		for _, change := range point.ScopeChanges {
+			row := []interface{}{
+				point.Period.End.Format("2006-01-02"),
+				change.Kind,
+				ticketLinkCell(config, change.Issue.Key, hyperlinkStyleID),
+				change.Issue.Fields.Summary,
+				excelize.Cell{StyleID: numStyleID, Value: change.Before},
+				excelize.Cell{StyleID: numStyleID, Value: change.After},
+				formulaCell(config, fmt.Sprintf("F%d-E%d", rowNum, rowNum), change.Delta(), numStyleID),
+			}
+			if err := sw.SetRow(fmt.Sprintf("A%d", rowNum), row); err != nil {
+				return errors.WithStack(err)
+			}
+			rowNum++
+		}
+	}
+
+	return errors.WithStack(sw.Flush())
+}
@@ -0,0 +1,96 @@
+package excel
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/xuri/excelize/v2"
+
+	"go-burndown/burndown"
+	"go-burndown/config"
+	"go-burndown/jira"
+)
+
+// writeSprintsSheet adds a sheet comparing committed and completed work per sprint, if periods are sprint-aligned.
+// Each row mirrors the same period row of the Projections sheet, so the sprint velocity is taken from there.
+func writeSprintsSheet(f *excelize.File, config *config.Config, issues []jira.Issue, projectionsSheet string, series burndown.Series, dateStyleID, percentStyleID, numStyleID int) error {
+	timeline := series.Timeline
+	if len(timeline.Periods) == 0 || timeline.Periods[0].Sprint == nil {
+		return nil
+	}
+
+	sprintsSheet := "Sprints"
+	if _, err := f.NewSheet(sprintsSheet); err != nil {
+		return errors.WithStack(err)
+	}
+
+	headers := []string{"Sprint", "Start", "End", unitHeader(config, "Committed"), unitHeader(config, "Completed"), "Completed %", unitHeader(config, "Velocity")}
+	for i, header := range headers {
+		cell, err := excelize.CoordinatesToCellName(i+1, 1)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		if err := f.SetCellValue(sprintsSheet, cell, header); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+
+	for periodIndex, period := range timeline.Periods {
+		rowNum := periodIndex + 2
+
+		commitment, err := burndown.CommitmentForSprint(config, issues, period.Sprint)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		if err := f.SetCellValue(sprintsSheet, fmt.Sprintf("A%d", rowNum), period.Sprint.Name); err != nil {
+			return errors.WithStack(err)
+		}
+		if err := f.SetCellValue(sprintsSheet, fmt.Sprintf("B%d", rowNum), period.Start); err != nil {
+			return errors.WithStack(err)
+		}
+		if err := f.SetCellValue(sprintsSheet, fmt.Sprintf("C%d", rowNum), period.End); err != nil {
+			return errors.WithStack(err)
+		}
+		if err := f.SetCellStyle(sprintsSheet, fmt.Sprintf("B%d", rowNum), fmt.Sprintf("C%d", rowNum), dateStyleID); err != nil {
+			return errors.WithStack(err)
+		}
+		if err := f.SetCellValue(sprintsSheet, fmt.Sprintf("D%d", rowNum), commitment.Committed); err != nil {
+			return errors.WithStack(err)
+		}
+		if err := f.SetCellValue(sprintsSheet, fmt.Sprintf("E%d", rowNum), commitment.Completed); err != nil {
+			return errors.WithStack(err)
+		}
+		if err := f.SetCellStyle(sprintsSheet, fmt.Sprintf("D%d", rowNum), fmt.Sprintf("E%d", rowNum), numStyleID); err != nil {
+			return errors.WithStack(err)
+		}
+
+		// How much of the commitment was met.
+		completedPercentCell := fmt.Sprintf("F%d", rowNum)
+		completedPercentFormula := fmt.Sprintf(`=IF(D%d=0, "", E%d/D%d)`, rowNum, rowNum, rowNum)
+		var completedPercent interface{}
+		if commitment.Committed != 0 {
+			completedPercent = commitment.Completed / commitment.Committed
+		}
+		if err := setFormula(f, config, sprintsSheet, completedPercentCell, completedPercentFormula, completedPercent); err != nil {
+			return errors.WithStack(err)
+		}
+		if err := f.SetCellStyle(sprintsSheet, completedPercentCell, completedPercentCell, percentStyleID); err != nil {
+			return errors.WithStack(err)
+		}
+
+		// The sprint velocity used for the forecast (the first period has none).
+		if periodIndex > 0 {
+			velocityCell := fmt.Sprintf("G%d", rowNum)
+			velocityFormula := fmt.Sprintf(`=%s!%s%d`, projectionsSheet, _COL_VELOCITY, rowNum)
+			if err := setFormula(f, config, sprintsSheet, velocityCell, velocityFormula, projectionValue(&series, periodIndex, _COL_VELOCITY)); err != nil {
+				return errors.WithStack(err)
+			}
+			if err := f.SetCellStyle(sprintsSheet, velocityCell, velocityCell, numStyleID); err != nil {
+				return errors.WithStack(err)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,203 @@
+package excel
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/xuri/excelize/v2"
+
+	"go-burndown/burndown"
+	"go-burndown/config"
+)
+
+// writeSummarySheet fills the Summary sheet with the headline figures of the latest period, next to those of the
+// previous period and the change since, followed by the open issues that make the forecast less reliable and a
+// small burndown chart. The figures are formulas on the Projections sheet, or their values, as configured.
+func writeSummarySheet(f *excelize.File, config *config.Config, summarySheet, projectionsSheet string, series burndown.Series, percentStyleID, dateStyleID, numStyleID int) error {
+	periods := series.Timeline.Periods
+	if len(periods) == 0 {
+		return nil
+	}
+	lastRow := len(periods) + 1
+
+	headers := []string{"", "Latest", "Previous", "Change"}
+	for i, header := range headers {
+		cell, err := excelize.CoordinatesToCellName(i+1, 1)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		if err := f.SetCellValue(summarySheet, cell, header); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+
+	// The figures are formulas of a Projections row, blank where the Projections sheet has none yet.
+	column := func(col string) string {
+		return fmt.Sprintf(`IF(%s!$%s$%%[1]d="", "", %s!$%s$%%[1]d)`, projectionsSheet, col, projectionsSheet, col)
+	}
+	columnValue := func(col string) func(periodIndex int) interface{} {
+		return func(periodIndex int) interface{} {
+			return projectionValue(&series, periodIndex, col)
+		}
+	}
+	kpis := []struct {
+		label   string
+		formula string                            // Formatted with the Projections row.
+		value   func(periodIndex int) interface{} // What the formula works out to for the period.
+		styleID int
+	}{
+		// The Projections dates are text, so they are read back as dates for the change in days.
+		{
+			"As of",
+			fmt.Sprintf(`IF(%s!$%s$%%[1]d="", "", DATEVALUE(%s!$%s$%%[1]d))`, projectionsSheet, _COL_DATE, projectionsSheet, _COL_DATE),
+			columnValue(_COL_DATE),
+			dateStyleID,
+		},
+		{unitHeader(config, "Scope"), column(_COL_SCOPE), columnValue(_COL_SCOPE), numStyleID},
+		{unitHeader(config, "Completed"), column(_COL_COMPLETED), columnValue(_COL_COMPLETED), numStyleID},
+		{unitHeader(config, "Remaining"), column(_COL_REMAINING), columnValue(_COL_REMAINING), numStyleID},
+		{
+			"% Complete",
+			fmt.Sprintf(`IF(%s!$%s$%%[1]d=0, "", %s!$%s$%%[1]d/%s!$%s$%%[1]d)`, projectionsSheet, _COL_SCOPE, projectionsSheet, _COL_COMPLETED, projectionsSheet, _COL_SCOPE),
+			func(periodIndex int) interface{} {
+				if point := series.Points[periodIndex]; point.Scope != 0 {
+					return point.Completed / point.Scope
+				}
+				return nil
+			},
+			percentStyleID,
+		},
+		{unitHeader(config, "Velocity"), column(_COL_VELOCITY), columnValue(_COL_VELOCITY), numStyleID},
+		{unitHeader(config, "Avg Velocity"), column(_COL_AVG_VELOCITY), columnValue(_COL_AVG_VELOCITY), numStyleID},
+		{
+			"Velocity vs Avg",
+			fmt.Sprintf(`IF(N(%s!$%s$%%[1]d)=0, "", %s!$%s$%%[1]d/%s!$%s$%%[1]d-1)`, projectionsSheet, _COL_AVG_VELOCITY, projectionsSheet, _COL_VELOCITY, projectionsSheet, _COL_AVG_VELOCITY),
+			func(periodIndex int) interface{} {
+				if point := series.Points[periodIndex]; point.HasAvg && point.AvgVelocity != 0 {
+					return point.Velocity/point.AvgVelocity - 1
+				}
+				return nil
+			},
+			percentStyleID,
+		},
+		{"Forecast Fast (p68)", column(_COL_FAST), columnValue(_COL_FAST), dateStyleID},
+		{"Forecast Mean", column(_COL_MEAN), columnValue(_COL_MEAN), dateStyleID},
+		{"Forecast Slow (p68)", column(_COL_SLOW), columnValue(_COL_SLOW), dateStyleID},
+	}
+
+	latestIndex := len(periods) - 1
+	for i, kpi := range kpis {
+		rowNum := i + 2
+		if err := f.SetCellValue(summarySheet, fmt.Sprintf("A%d", rowNum), kpi.label); err != nil {
+			return errors.WithStack(err)
+		}
+
+		latestCell := fmt.Sprintf("B%d", rowNum)
+		latest := kpi.value(latestIndex)
+		if err := setFormula(f, config, summarySheet, latestCell, "="+fmt.Sprintf(kpi.formula, lastRow), latest); err != nil {
+			return errors.WithStack(err)
+		}
+
+		// The previous report's figure, and the change since (in days, for dates).
+		previousCell := fmt.Sprintf("C%d", rowNum)
+		changeCell := fmt.Sprintf("D%d", rowNum)
+		if latestIndex > 0 {
+			previous := kpi.value(latestIndex - 1)
+			if err := setFormula(f, config, summarySheet, previousCell, "="+fmt.Sprintf(kpi.formula, lastRow-1), previous); err != nil {
+				return errors.WithStack(err)
+			}
+			changeFormula := fmt.Sprintf(`=IF(OR(%s="", %s=""), "", %s-%s)`, latestCell, previousCell, latestCell, previousCell)
+			if err := setFormula(f, config, summarySheet, changeCell, changeFormula, changeValue(latest, previous)); err != nil {
+				return errors.WithStack(err)
+			}
+		}
+
+		if err := f.SetCellStyle(summarySheet, latestCell, previousCell, kpi.styleID); err != nil {
+			return errors.WithStack(err)
+		}
+		changeStyleID := kpi.styleID
+		if kpi.styleID == dateStyleID {
+			changeStyleID = numStyleID
+		}
+		if err := f.SetCellStyle(summarySheet, changeCell, changeCell, changeStyleID); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+
+	// Open issues that make the forecast less reliable, as they stand now.
+	date := periods[len(periods)-1].End
+	unestimated, stale := 0, 0
+	for i := range series.Issues {
+		issue := series.Issues[i].Issue
+		if issue.IsUnestimated(config) {
+			unestimated++
+		}
+		isStale, err := issue.IsStaleOnDate(config, date)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		if isStale {
+			stale++
+		}
+	}
+	issueCounts := []struct {
+		label string
+		count int
+	}{
+		{"Unestimated Issues", unestimated},
+		{fmt.Sprintf("Stale Issues (%d+ days)", config.StaleDaysOrDefault()), stale},
+	}
+	for i, issueCount := range issueCounts {
+		rowNum := len(kpis) + 3 + i
+		if err := f.SetCellValue(summarySheet, fmt.Sprintf("A%d", rowNum), issueCount.label); err != nil {
+			return errors.WithStack(err)
+		}
+		if err := f.SetCellValue(summarySheet, fmt.Sprintf("B%d", rowNum), issueCount.count); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+
+	if err := f.SetColWidth(summarySheet, "A", "A", 24); err != nil {
+		return errors.WithStack(err)
+	}
+	if err := f.SetColWidth(summarySheet, "B", "D", 12); err != nil {
+		return errors.WithStack(err)
+	}
+
+	// A small burndown of the remaining work against the scope.
+	values := func(col string) string {
+		return fmt.Sprintf("%s!$%s$2:$%s$%d", projectionsSheet, col, col, lastRow)
+	}
+	burndownChart := &excelize.Chart{
+		Type: excelize.Line,
+		Series: []excelize.ChartSeries{
+			{Name: fmt.Sprintf("%s!$%s$1", projectionsSheet, _COL_REMAINING), Categories: values(_COL_DATE), Values: values(_COL_REMAINING)},
+			{Name: fmt.Sprintf("%s!$%s$1", projectionsSheet, _COL_SCOPE), Categories: values(_COL_DATE), Values: values(_COL_SCOPE)},
+		},
+		Title:     []excelize.RichTextRun{{Text: "Burndown"}},
+		Legend:    excelize.ChartLegend{Position: "bottom"},
+		Dimension: excelize.ChartDimension{Width: 480, Height: 288},
+		YAxis:     excelize.ChartAxis{MajorGridLines: true},
+	}
+	if err := f.AddChart(summarySheet, "F1", burndownChart); err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+// changeValue is the change from the previous figure to the latest, in days for dates, or nil if either is blank.
+func changeValue(latest, previous interface{}) interface{} {
+	switch latest := latest.(type) {
+	case float64:
+		if previous, ok := previous.(float64); ok {
+			return latest - previous
+		}
+	case time.Time:
+		if previous, ok := previous.(time.Time); ok {
+			return latest.Sub(previous).Hours() / 24
+		}
+	}
+	return nil
+}
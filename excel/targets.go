@@ -0,0 +1,139 @@
+package excel
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/xuri/excelize/v2"
+
+	"go-burndown/burndown"
+	"go-burndown/config"
+)
+
+// writeTargetsSheet adds a sheet analyzing whether each configured target date can be met.
+// Each row mirrors the same period row of the Projections sheet, and each target gets three columns:
+// the probability of finishing by the target, the velocity required to hit it, and the scope
+// that would need to be cut to hit it at the current average velocity.
+func writeTargetsSheet(f *excelize.File, config *config.Config, projectionsSheet string, series burndown.Series, percentStyleID, numStyleID int) error {
+	if len(config.TargetDates) == 0 {
+		return nil
+	}
+	timeline := series.Timeline
+
+	targetsSheet := "Targets"
+	if _, err := f.NewSheet(targetsSheet); err != nil {
+		return errors.WithStack(err)
+	}
+
+	if err := f.SetCellValue(targetsSheet, "A1", "Date"); err != nil {
+		return errors.WithStack(err)
+	}
+
+	workdaysPerPeriod := strconv.FormatFloat(timeline.WorkdaysPerPeriod, 'f', -1, 64)
+
+	for targetIndex, targetDateStr := range config.TargetDates {
+		targetDate, err := time.Parse("2006-01-02", targetDateStr)
+		if err != nil {
+			return errors.Wrapf(err, "invalid target date format: %s", targetDateStr)
+		}
+		targetDateExpr := fmt.Sprintf("DATE(%d,%d,%d)", targetDate.Year(), targetDate.Month(), targetDate.Day())
+
+		// Three columns per target, after the date column.
+		probabilityCol := 2 + targetIndex*3
+		requiredCol := probabilityCol + 1
+		cutCol := probabilityCol + 2
+
+		headers := map[int]string{
+			probabilityCol: fmt.Sprintf("P(by %s)", targetDateStr),
+			requiredCol:    unitHeader(config, fmt.Sprintf("Req. Velocity (%s)", targetDateStr)),
+			cutCol:         unitHeader(config, fmt.Sprintf("Cut (%s)", targetDateStr)),
+		}
+		for col, header := range headers {
+			cell, err := excelize.CoordinatesToCellName(col, 1)
+			if err != nil {
+				return errors.WithStack(err)
+			}
+			if err := f.SetCellValue(targetsSheet, cell, header); err != nil {
+				return errors.WithStack(err)
+			}
+		}
+
+		for periodIndex, period := range timeline.Periods {
+			rowNum := periodIndex + 2
+
+			if targetIndex == 0 {
+				dateCell := fmt.Sprintf("A%d", rowNum)
+				if err := f.SetCellValue(targetsSheet, dateCell, period.End.Format("2006-01-02")); err != nil {
+					return errors.WithStack(err)
+				}
+			}
+
+			outlook := series.TargetOutlook(periodIndex, targetDate)
+
+			// The Projections cells this row is computed from.
+			dateCell := fmt.Sprintf("%s!%s%d", projectionsSheet, _COL_DATE, rowNum)
+			remainingCell := fmt.Sprintf("%s!%s%d", projectionsSheet, _COL_REMAINING, rowNum)
+			avgVelocityCell := fmt.Sprintf("%s!%s%d", projectionsSheet, _COL_AVG_VELOCITY, rowNum)
+			stdVelocityCell := fmt.Sprintf("%s!%s%d", projectionsSheet, _COL_STD_VELOCITY, rowNum)
+
+			// Periods left until the target, counted in workdays the same way the projections use WORKDAY.
+			periodsLeft := fmt.Sprintf("(NETWORKDAYS(%s+1, %s)/%s)", dateCell, targetDateExpr, workdaysPerPeriod)
+
+			// Velocity required to burn the remaining work by the target.
+			requiredCell, err := excelize.CoordinatesToCellName(requiredCol, rowNum)
+			if err != nil {
+				return errors.WithStack(err)
+			}
+			requiredFormula := fmt.Sprintf(`=IF(%s<=0, "", MAX(0, %s)/%s)`, periodsLeft, remainingCell, periodsLeft)
+			var required interface{}
+			if outlook.HasRequiredVelocity {
+				required = outlook.RequiredVelocity
+			}
+			if err := setFormula(f, config, targetsSheet, requiredCell, requiredFormula, required); err != nil {
+				return errors.WithStack(err)
+			}
+			if err := f.SetCellStyle(targetsSheet, requiredCell, requiredCell, numStyleID); err != nil {
+				return errors.WithStack(err)
+			}
+
+			// Scope cut requires an average velocity (see Projections).
+			if periodIndex > 1 {
+				cutCell, err := excelize.CoordinatesToCellName(cutCol, rowNum)
+				if err != nil {
+					return errors.WithStack(err)
+				}
+				cutFormula := fmt.Sprintf(`=MAX(0, %s - %s*MAX(0, %s))`, remainingCell, avgVelocityCell, periodsLeft)
+				if err := setFormula(f, config, targetsSheet, cutCell, cutFormula, outlook.Cut); err != nil {
+					return errors.WithStack(err)
+				}
+				if err := f.SetCellStyle(targetsSheet, cutCell, cutCell, numStyleID); err != nil {
+					return errors.WithStack(err)
+				}
+			}
+
+			// Probability requires a standard deviation (see Projections).
+			// Velocity is treated as normally distributed, so the chance of making the target is
+			// the chance that velocity is at least the required velocity.
+			if periodIndex > 2 {
+				probabilityCell, err := excelize.CoordinatesToCellName(probabilityCol, rowNum)
+				if err != nil {
+					return errors.WithStack(err)
+				}
+				probabilityFormula := fmt.Sprintf(`=IF(%s<=0, 1, IF(%s<=0, 0, IF(%s=0, IF(%s>=%s, 1, 0), 1-NORMDIST(%s, %s, %s, TRUE))))`,
+					remainingCell, periodsLeft,
+					stdVelocityCell, avgVelocityCell, requiredCell,
+					requiredCell, avgVelocityCell, stdVelocityCell)
+				if err := setFormula(f, config, targetsSheet, probabilityCell, probabilityFormula, outlook.Probability); err != nil {
+					return errors.WithStack(err)
+				}
+				if err := f.SetCellStyle(targetsSheet, probabilityCell, probabilityCell, percentStyleID); err != nil {
+					return errors.WithStack(err)
+				}
+			}
+		}
+	}
+
+	return nil
+}
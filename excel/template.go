@@ -0,0 +1,95 @@
+package excel
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/xuri/excelize/v2"
+
+	"go-burndown/burndown"
+	"go-burndown/config"
+)
+
+const (
+	// Defined names of template cells whose style replaces a built-in style.
+	//revive:disable:var-naming
+	_TEMPLATE_HYPERLINK_STYLE = "Burndown_HyperlinkStyle"
+	_TEMPLATE_PERCENT_STYLE   = "Burndown_PercentStyle"
+	_TEMPLATE_DATE_STYLE      = "Burndown_DateStyle"
+	_TEMPLATE_NUMBER_STYLE    = "Burndown_NumberStyle"
+)
+
+// templateAnchors are the defined names of template cells, such as on a cover sheet, that show the latest period's
+// figures, by the Projections column they come from.
+var templateAnchors = []struct {
+	name string
+	col  string
+}{
+	{"Burndown_ReportDate", _COL_DATE},
+	{"Burndown_Completed", _COL_COMPLETED},
+	{"Burndown_Remaining", _COL_REMAINING},
+	{"Burndown_Scope", _COL_SCOPE},
+	{"Burndown_Velocity", _COL_AVG_VELOCITY},
+	{"Burndown_FastForecast", _COL_FAST},
+	{"Burndown_MeanForecast", _COL_MEAN},
+	{"Burndown_SlowForecast", _COL_SLOW},
+}
+
+// definedNameCell locates the cell a workbook-wide defined name refers to, such as Cover!$B$4.
+// Names that refer to a range use its first cell.
+func definedNameCell(f *excelize.File, name string) (sheet, cell string, ok bool) {
+	for _, definedName := range f.GetDefinedName() {
+		if definedName.Name != name || (definedName.Scope != "" && definedName.Scope != "Workbook") {
+			continue
+		}
+		ref := strings.TrimPrefix(definedName.RefersTo, "=")
+		separator := strings.LastIndex(ref, "!")
+		if separator < 0 {
+			return "", "", false
+		}
+		sheet = strings.ReplaceAll(strings.Trim(ref[:separator], "'"), "''", "'")
+		cell, _, _ = strings.Cut(strings.ReplaceAll(ref[separator+1:], "$", ""), ":")
+		return sheet, cell, true
+	}
+	return "", "", false
+}
+
+// templateStyle returns the style of the template cell with the defined name, so reports take the template's fonts,
+// colors and number formats. It returns the built-in style if the workbook has no such name.
+func templateStyle(f *excelize.File, name string, styleID int) (int, error) {
+	sheet, cell, ok := definedNameCell(f, name)
+	if !ok {
+		return styleID, nil
+	}
+	templateStyleID, err := f.GetCellStyle(sheet, cell)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to read the style of %s", name)
+	}
+	return templateStyleID, nil
+}
+
+// writeTemplateAnchors fills the template cells with anchor names with formulas for the latest period's row of the
+// Projections sheet, or their values, as configured. The cells keep the template's formatting.
+func writeTemplateAnchors(f *excelize.File, config *config.Config, projectionsSheet string, series burndown.Series) error {
+	periods := series.Timeline.Periods
+	if len(periods) == 0 {
+		return nil
+	}
+	lastRow := len(periods) + 1
+
+	for _, anchor := range templateAnchors {
+		sheet, cell, ok := definedNameCell(f, anchor.name)
+		if !ok {
+			continue
+		}
+		// Blank rather than zero until there is a forecast.
+		projectionsCell := fmt.Sprintf("%s!$%s$%d", projectionsSheet, anchor.col, lastRow)
+		formula := fmt.Sprintf(`=IF(%s="", "", %s)`, projectionsCell, projectionsCell)
+		if err := setFormula(f, config, sheet, cell, formula, projectionValue(&series, len(periods)-1, anchor.col)); err != nil {
+			return errors.Wrapf(err, "failed to fill %s", anchor.name)
+		}
+	}
+
+	return nil
+}
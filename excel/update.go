@@ -0,0 +1,326 @@
+package excel
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/xuri/excelize/v2"
+
+	"go-burndown/config"
+)
+
+const (
+	// The workbook property listing the sheets a run generated, so an update knows which sheets are its own.
+	//revive:disable:var-naming
+	_GENERATED_SHEETS_PROPERTY = "go-burndown generated sheets"
+)
+
+// workAnnotations are the columns and comments users added to the Work sheet, keyed by issue key
+// so they follow their issues into the regenerated sheet.
+type workAnnotations struct {
+	headers []string
+	// values are parallel to the headers, for each issue key in the order the issues were listed. Each keeps
+	// its type, formula and style.
+	keys     []string
+	values   map[string][]excelize.Cell
+	comments []workComment
+}
+
+// workComment is a comment on the Work sheet, located by its row's issue key (empty for the header row) and its column header.
+type workComment struct {
+	key     string
+	header  string
+	comment excelize.Comment
+}
+
+// openWorkbook starts the workbook with an empty Work sheet. Normally the workbook is new, or a copy of the template
+// whose sheets are all kept, but when updating an existing output file it is opened, the sheets a previous run
+// generated are removed and everything else is kept. The sheets that weren't generated, from the template or kept,
+// are returned along with the annotations users made on the old Work sheet, so they can be carried over.
+func openWorkbook(config *config.Config, workSheet string, workHeaders []string) (f *excelize.File, userSheets []string, annotations workAnnotations, err error) {
+	_, statErr := os.Stat(config.OutputFile)
+	if (!config.Update || os.IsNotExist(statErr)) && config.Template != "" {
+		// Template sheets named like a generated sheet are written into, keeping their formatting.
+		f, err = excelize.OpenFile(config.Template)
+		if err != nil {
+			return nil, nil, workAnnotations{}, errors.Wrap(err, "failed to open template")
+		}
+		userSheets = f.GetSheetList()
+		if _, err := f.NewSheet(workSheet); err != nil {
+			return nil, nil, workAnnotations{}, errors.Wrap(err, "failed to create work sheet")
+		}
+		return f, userSheets, workAnnotations{}, nil
+	}
+	if !config.Update || os.IsNotExist(statErr) {
+		// The default sheet is renamed rather than deleted later, since deleting it would read the streamed
+		// Work sheet back into memory.
+		f = excelize.NewFile()
+		if err := f.SetSheetName("Sheet1", workSheet); err != nil {
+			return nil, nil, workAnnotations{}, errors.Wrap(err, "failed to create work sheet")
+		}
+		return f, nil, workAnnotations{}, nil
+	}
+
+	f, err = excelize.OpenFile(config.OutputFile)
+	if err != nil {
+		return nil, nil, workAnnotations{}, errors.WithStack(err)
+	}
+
+	if slices.Contains(f.GetSheetList(), workSheet) {
+		annotations, err = readWorkAnnotations(f, workSheet, workHeaders)
+		if err != nil {
+			return nil, nil, workAnnotations{}, errors.WithStack(err)
+		}
+	}
+
+	generatedSheets, err := readGeneratedSheets(f)
+	if err != nil {
+		return nil, nil, workAnnotations{}, errors.WithStack(err)
+	}
+
+	// The new Work sheet is created under a temporary name first, since the last sheet of a workbook can't be
+	// deleted, so a workbook of only generated sheets would keep one of them.
+	newWorkSheet := workSheet + " (new)"
+	if _, err := f.NewSheet(newWorkSheet); err != nil {
+		return nil, nil, workAnnotations{}, errors.Wrap(err, "failed to create work sheet")
+	}
+	for _, sheet := range generatedSheets {
+		if !slices.Contains(f.GetSheetList(), sheet) {
+			continue
+		}
+		// Deleting a sheet leaves its tables behind, and their names would clash with the regenerated ones.
+		tables, err := f.GetTables(sheet)
+		if err != nil {
+			return nil, nil, workAnnotations{}, errors.WithStack(err)
+		}
+		for _, table := range tables {
+			if err := f.DeleteTable(table.Name); err != nil {
+				return nil, nil, workAnnotations{}, errors.WithStack(err)
+			}
+		}
+		// Formulas and charts on the kept sheets still name the removed sheets, so they pick up the regenerated ones.
+		if err := f.DeleteSheet(sheet); err != nil {
+			return nil, nil, workAnnotations{}, errors.WithStack(err)
+		}
+	}
+
+	userSheets = slices.DeleteFunc(f.GetSheetList(), func(sheet string) bool {
+		return sheet == newWorkSheet
+	})
+
+	// The Work sheet goes first, ahead of the kept sheets.
+	if err := f.SetSheetName(newWorkSheet, workSheet); err != nil {
+		return nil, nil, workAnnotations{}, errors.Wrap(err, "failed to create work sheet")
+	}
+	if sheets := f.GetSheetList(); sheets[0] != workSheet {
+		if err := f.MoveSheet(workSheet, sheets[0]); err != nil {
+			return nil, nil, workAnnotations{}, errors.WithStack(err)
+		}
+	}
+
+	return f, userSheets, annotations, nil
+}
+
+// readGeneratedSheets lists the sheets recorded as generated. Workbooks from before sheets were recorded
+// are taken to hold the report's own sheets, by name, and the group sheets the Groups sheet links to.
+func readGeneratedSheets(f *excelize.File) ([]string, error) {
+	props, err := f.GetCustomProps()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	for _, prop := range props {
+		if prop.Name != _GENERATED_SHEETS_PROPERTY {
+			continue
+		}
+		var sheets []string
+		if value, ok := prop.Value.(string); ok {
+			if err := json.Unmarshal([]byte(value), &sheets); err != nil {
+				return nil, errors.WithStack(err)
+			}
+		}
+		return sheets, nil
+	}
+
+	sheets := slices.DeleteFunc(f.GetSheetList(), func(sheet string) bool {
+		return !isReservedSheetName(sheet)
+	})
+	groupsSheet := slices.IndexFunc(sheets, func(sheet string) bool {
+		return strings.EqualFold(sheet, "Groups")
+	})
+	if groupsSheet < 0 {
+		return sheets, nil
+	}
+	rows, err := f.GetRows(sheets[groupsSheet])
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	for rowNum := 2; rowNum <= len(rows); rowNum++ {
+		ok, target, err := f.GetCellHyperLink(sheets[groupsSheet], fmt.Sprintf("A%d", rowNum))
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		// Group names never contain quotes, so the link is just the sheet name quoted.
+		if sheet, found := strings.CutSuffix(target, "'!A1"); ok && found {
+			sheets = append(sheets, strings.TrimPrefix(sheet, "'"))
+		}
+	}
+	return sheets, nil
+}
+
+// recordGeneratedSheets saves which sheets this run generated: every sheet but the kept ones.
+func recordGeneratedSheets(f *excelize.File, keptSheets []string) error {
+	var generatedSheets []string
+	for _, sheet := range f.GetSheetList() {
+		if !slices.Contains(keptSheets, sheet) {
+			generatedSheets = append(generatedSheets, sheet)
+		}
+	}
+	value, err := json.Marshal(generatedSheets)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return errors.WithStack(f.SetCustomProps(excelize.CustomProperty{Name: _GENERATED_SHEETS_PROPERTY, Value: string(value)}))
+}
+
+// readWorkAnnotations collects the columns of the Work sheet that weren't generated, and the sheet's comments.
+// Period columns of any date count as generated, since the periods move on between runs.
+func readWorkAnnotations(f *excelize.File, workSheet string, workHeaders []string) (annotations workAnnotations, err error) {
+	rows, err := f.GetRows(workSheet, excelize.Options{RawCellValue: true})
+	if err != nil {
+		return workAnnotations{}, errors.WithStack(err)
+	}
+	if len(rows) == 0 {
+		return workAnnotations{}, nil
+	}
+
+	isGenerated := func(header string) bool {
+		return slices.Contains(workHeaders, header) || strings.HasPrefix(header, "% ") || strings.HasPrefix(header, "EV ")
+	}
+	keyCol := slices.Index(rows[0], "Issue Key")
+	if keyCol < 0 {
+		return workAnnotations{}, nil
+	}
+	var annotationCols []int
+	for col, header := range rows[0] {
+		if header != "" && !isGenerated(header) {
+			annotationCols = append(annotationCols, col)
+			annotations.headers = append(annotations.headers, header)
+		}
+	}
+
+	cellValue := func(row []string, col int) string {
+		if col < len(row) {
+			return row[col]
+		}
+		return ""
+	}
+	annotations.values = map[string][]excelize.Cell{}
+	keysByRow := map[int]string{}
+	for rowIndex, row := range rows[1:] {
+		rowNum := rowIndex + 2
+		key := cellValue(row, keyCol)
+		if key == "" {
+			continue
+		}
+		keysByRow[rowNum] = key
+		if len(annotationCols) == 0 {
+			continue
+		}
+		values := make([]excelize.Cell, len(annotationCols))
+		for i, col := range annotationCols {
+			cell, err := excelize.CoordinatesToCellName(col+1, rowNum)
+			if err != nil {
+				return workAnnotations{}, errors.WithStack(err)
+			}
+			cellType, err := f.GetCellType(workSheet, cell)
+			if err != nil {
+				return workAnnotations{}, errors.WithStack(err)
+			}
+			formula, err := f.GetCellFormula(workSheet, cell)
+			if err != nil {
+				return workAnnotations{}, errors.WithStack(err)
+			}
+			styleID, err := f.GetCellStyle(workSheet, cell)
+			if err != nil {
+				return workAnnotations{}, errors.WithStack(err)
+			}
+			values[i] = excelize.Cell{StyleID: styleID, Formula: formula, Value: annotationValue(cellValue(row, col), cellType)}
+		}
+		if _, ok := annotations.values[key]; !ok {
+			annotations.keys = append(annotations.keys, key)
+		}
+		annotations.values[key] = values
+	}
+
+	comments, err := f.GetComments(workSheet)
+	if err != nil {
+		return workAnnotations{}, errors.WithStack(err)
+	}
+	for _, comment := range comments {
+		col, row, err := excelize.CellNameToCoordinates(comment.Cell)
+		if err != nil {
+			return workAnnotations{}, errors.WithStack(err)
+		}
+		key, ok := keysByRow[row]
+		if row > 1 && !ok {
+			continue
+		}
+		annotations.comments = append(annotations.comments, workComment{key: key, header: cellValue(rows[0], col-1), comment: comment})
+	}
+
+	return annotations, nil
+}
+
+// annotationValue reads a raw cell value back as the type it was stored as, so numbers stay numbers. Numbers are
+// usually stored without a type.
+func annotationValue(raw string, cellType excelize.CellType) interface{} {
+	if raw == "" {
+		return nil
+	}
+	switch cellType {
+	case excelize.CellTypeBool:
+		return raw == "1"
+	case excelize.CellTypeUnset, excelize.CellTypeNumber:
+		if number, err := strconv.ParseFloat(raw, 64); err == nil {
+			return number
+		}
+	}
+	return raw
+}
+
+// restoreWorkComments puts the comments back on the cells under the same header in the same issue's row,
+// dropping any whose issue or column is gone.
+func restoreWorkComments(f *excelize.File, workSheet string, headers []string, keys []string, annotations workAnnotations) error {
+	rowsByKey := make(map[string]int, len(keys))
+	for keyIndex, key := range keys {
+		rowsByKey[key] = keyIndex + 2
+	}
+	for _, comment := range annotations.comments {
+		col := slices.Index(headers, comment.header)
+		if col < 0 {
+			continue
+		}
+		row := 1
+		if comment.key != "" {
+			keyRow, ok := rowsByKey[comment.key]
+			if !ok {
+				continue
+			}
+			row = keyRow
+		}
+		cell, err := excelize.CoordinatesToCellName(col+1, row)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		comment.comment.Cell = cell
+		if err := f.AddComment(workSheet, comment.comment); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,255 @@
+package excel
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
+
+	"go-burndown/config"
+)
+
+var testWorkHeaders = []string{"Issue Key", "Summary", "Type", "Status", "Assignee", "Size"}
+
+// newAnnotatedWorkbook builds a workbook with a Work sheet from a previous run, with columns of notes, estimates
+// and flags added to it, and comments on the notes and the progress.
+func newAnnotatedWorkbook(t *testing.T) *excelize.File {
+	f := excelize.NewFile()
+	require.NoError(t, f.SetSheetName("Sheet1", "Work"))
+	rows := [][]interface{}{
+		{"Issue Key", "Summary", "Type", "Status", "Assignee", "Size", "% 2025-01-03", "EV 2025-01-03", "Notes", "Estimate", "Flagged"},
+		{"A-1", "Login", "Story", "Done", "Ada", 3, 1, 3, "blocked on design", 3.5, true},
+		{"A-2", "Logout", "Story", "To Do", "", 2, nil, nil, nil, nil, false},
+		{"", "Totals"},
+	}
+	for i, row := range rows {
+		require.NoError(t, f.SetSheetRow("Work", fmt.Sprintf("A%d", i+1), &row))
+	}
+	require.NoError(t, f.SetCellFormula("Work", "J3", "F3*2"))
+	styleID, err := f.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}})
+	require.NoError(t, err)
+	require.NoError(t, f.SetCellStyle("Work", "I2", "I2", styleID))
+
+	for _, comment := range []excelize.Comment{
+		{Cell: "I1", Author: "Ada", Text: "Why it's late"},
+		{Cell: "I2", Author: "Ada", Text: "Waiting on Grace"},
+		{Cell: "G2", Author: "Ada", Text: "Finished early"},
+		{Cell: "B4", Author: "Ada", Text: "Not an issue"},
+	} {
+		require.NoError(t, f.AddComment("Work", comment))
+	}
+	return f
+}
+
+func TestReadWorkAnnotations(t *testing.T) {
+	f := newAnnotatedWorkbook(t)
+	boldStyleID, err := f.GetCellStyle("Work", "I2")
+	require.NoError(t, err)
+
+	annotations, err := readWorkAnnotations(f, "Work", testWorkHeaders)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"Notes", "Estimate", "Flagged"}, annotations.headers)
+	assert.Equal(t, []string{"A-1", "A-2"}, annotations.keys)
+	assert.Equal(t, map[string][]excelize.Cell{
+		"A-1": {{StyleID: boldStyleID, Value: "blocked on design"}, {Value: 3.5}, {Value: true}},
+		"A-2": {{}, {Formula: "F3*2"}, {Value: false}},
+	}, annotations.values)
+
+	var comments []workComment
+	for _, comment := range annotations.comments {
+		comment.comment = excelize.Comment{Text: comment.comment.Text}
+		comments = append(comments, comment)
+	}
+	assert.ElementsMatch(t, []workComment{
+		{key: "", header: "Notes", comment: excelize.Comment{Text: "Why it's late"}},
+		{key: "A-1", header: "Notes", comment: excelize.Comment{Text: "Waiting on Grace"}},
+		{key: "A-1", header: "% 2025-01-03", comment: excelize.Comment{Text: "Finished early"}},
+	}, comments)
+}
+
+func TestRestoreWorkComments(t *testing.T) {
+	annotations, err := readWorkAnnotations(newAnnotatedWorkbook(t), "Work", testWorkHeaders)
+	require.NoError(t, err)
+
+	// The issues are listed in another order, and the period moved on, dropping its column.
+	f := excelize.NewFile()
+	headers := append(append([]string{}, testWorkHeaders...), "% 2025-01-10", "EV 2025-01-10", "Notes", "Estimate", "Flagged")
+	require.NoError(t, restoreWorkComments(f, "Sheet1", headers, []string{"A-2", "A-1"}, annotations))
+
+	comments, err := f.GetComments("Sheet1")
+	require.NoError(t, err)
+	restored := map[string]string{}
+	for _, comment := range comments {
+		restored[comment.Cell] = comment.Text
+	}
+	assert.Equal(t, map[string]string{"I1": "Why it's late", "I3": "Waiting on Grace"}, restored)
+}
+
+func TestOpenWorkbookUpdate(t *testing.T) {
+	tests := []struct {
+		name string
+		// generatedSheets is recorded in the workbook when set; otherwise the sheets are told apart by name.
+		generatedSheets []string
+		userSheets      []string
+	}{
+		{
+			name:            "recorded generated sheets",
+			generatedSheets: []string{"Work", "Summary", "Projections", "Groups", "Team A", "Notes"},
+			userSheets:      []string{"Mine"},
+		},
+		{
+			name:       "workbooks from before generated sheets were recorded",
+			userSheets: []string{"Notes", "Mine"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newAnnotatedWorkbook(t)
+			for _, sheet := range []string{"Summary", "Projections", "Groups", "Team A", "Notes", "Mine"} {
+				_, err := f.NewSheet(sheet)
+				require.NoError(t, err)
+			}
+			require.NoError(t, f.SetCellValue("Groups", "A2", "Team A"))
+			require.NoError(t, f.SetCellHyperLink("Groups", "A2", "'Team A'!A1", "Location"))
+			table, err := sheetTable("ProjectionsTable", 2, 2)
+			require.NoError(t, err)
+			require.NoError(t, f.AddTable("Projections", table))
+			if tt.generatedSheets != nil {
+				require.NoError(t, recordGeneratedSheets(f, slices.DeleteFunc(f.GetSheetList(), func(sheet string) bool {
+					return slices.Contains(tt.generatedSheets, sheet)
+				})))
+			}
+			path := filepath.Join(t.TempDir(), "burndown.xlsx")
+			require.NoError(t, f.SaveAs(path))
+
+			f, userSheets, annotations, err := openWorkbook(&config.Config{OutputFile: path, Update: true}, "Work", testWorkHeaders)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.userSheets, userSheets)
+			assert.Equal(t, append([]string{"Work"}, tt.userSheets...), f.GetSheetList())
+			assert.Equal(t, []string{"A-1", "A-2"}, annotations.keys)
+			rows, err := f.GetRows("Work")
+			require.NoError(t, err)
+			assert.Empty(t, rows, "the Work sheet starts empty")
+			// The regenerated Projections sheet can take its table's name again.
+			_, err = f.NewSheet("Projections")
+			require.NoError(t, err)
+			assert.NoError(t, f.AddTable("Projections", table))
+		})
+	}
+}
+
+func TestGenerateExcelReportUpdate(t *testing.T) {
+	tests := []struct {
+		name string
+		// legacy drops the record of the generated sheets, as in workbooks from before they were recorded.
+		legacy bool
+	}{
+		{name: "recorded generated sheets"},
+		{name: "workbooks from before generated sheets were recorded", legacy: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues := testIssues(t, "A-1", "A-2", "A-3")
+			config := testConfig(filepath.Join(t.TempDir(), "burndown.xlsx"))
+			config.Update = true
+			require.NoError(t, GenerateExcelReport(config, issues, testTimeline))
+
+			// Annotate the generated workbook as a user would.
+			f, err := excelize.OpenFile(config.OutputFile)
+			require.NoError(t, err)
+			_, err = f.NewSheet("Mine")
+			require.NoError(t, err)
+			require.NoError(t, f.SetCellFormula("Mine", "A1", "Projections!B2"))
+			headers, err := f.GetRows("Work")
+			require.NoError(t, err)
+			notesCol := len(headers[0]) + 1
+			cell := func(col, row int) string {
+				name, err := excelize.CoordinatesToCellName(col, row)
+				require.NoError(t, err)
+				return name
+			}
+			require.NoError(t, f.SetSheetRow("Work", cell(notesCol, 1), &[]interface{}{"Notes", "Estimate", "Flagged"}))
+			// A-2 is in row 3, A-3 in row 4.
+			require.NoError(t, f.SetSheetRow("Work", cell(notesCol, 3), &[]interface{}{"waiting on design", 2.5, true}))
+			require.NoError(t, f.SetCellFormula("Work", cell(notesCol+1, 4), "F4*2"))
+			require.NoError(t, f.AddComment("Work", excelize.Comment{Cell: cell(notesCol, 1), Author: "Ada", Text: "Why it's late"}))
+			require.NoError(t, f.AddComment("Work", excelize.Comment{Cell: cell(notesCol, 3), Author: "Ada", Text: "Asked Grace"}))
+			if tt.legacy {
+				require.NoError(t, f.SetCustomProps(excelize.CustomProperty{Name: _GENERATED_SHEETS_PROPERTY}))
+			}
+			require.NoError(t, f.Save())
+			require.NoError(t, f.Close())
+
+			// The issues come back in another order: A-2, A-3, A-1.
+			require.NoError(t, GenerateExcelReport(config, testIssues(t, "A-2", "A-3", "A-1"), testTimeline))
+
+			f, err = excelize.OpenFile(config.OutputFile)
+			require.NoError(t, err)
+			defer f.Close()
+
+			sheets := f.GetSheetList()
+			assert.Contains(t, sheets, "Mine")
+			for _, sheet := range []string{"Summary", "Work", "Projections"} {
+				assert.Equal(t, 1, countOf(sheets, sheet), "%s appears once", sheet)
+			}
+			formula, err := f.GetCellFormula("Mine", "A1")
+			require.NoError(t, err)
+			assert.Equal(t, "Projections!B2", formula, "the kept sheet is untouched")
+
+			rows, err := f.GetRows("Work")
+			require.NoError(t, err)
+			assert.Equal(t, []string{"A-2", "A-3", "A-1"}, []string{rows[1][0], rows[2][0], rows[3][0]})
+			notesCol = slices.Index(rows[0], "Notes") + 1
+			require.Positive(t, notesCol)
+			assert.Equal(t, []string{"Notes", "Estimate", "Flagged"}, rows[0][notesCol-1:])
+
+			// A-2's notes moved up to row 2, keeping their types; A-3's formula moved with it to row 3, as written.
+			notes, err := f.GetCellValue("Work", cell(notesCol, 2))
+			require.NoError(t, err)
+			assert.Equal(t, "waiting on design", notes)
+			for _, expected := range []struct {
+				cell     string
+				cellType excelize.CellType
+				raw      string
+			}{
+				{cell(notesCol+1, 2), excelize.CellTypeUnset, "2.5"},
+				{cell(notesCol+2, 2), excelize.CellTypeBool, "1"},
+			} {
+				cellType, err := f.GetCellType("Work", expected.cell)
+				require.NoError(t, err)
+				raw, err := f.GetCellValue("Work", expected.cell, excelize.Options{RawCellValue: true})
+				require.NoError(t, err)
+				assert.Equal(t, expected.cellType, cellType, expected.cell)
+				assert.Equal(t, expected.raw, raw, expected.cell)
+			}
+			formula, err = f.GetCellFormula("Work", cell(notesCol+1, 3))
+			require.NoError(t, err)
+			assert.Equal(t, "F4*2", formula)
+
+			comments, err := f.GetComments("Work")
+			require.NoError(t, err)
+			restored := map[string]string{}
+			for _, comment := range comments {
+				restored[comment.Cell] = comment.Text
+			}
+			assert.Equal(t, map[string]string{cell(notesCol, 1): "Why it's late", cell(notesCol, 2): "Asked Grace"}, restored)
+		})
+	}
+}
+
+func countOf(values []string, value string) (count int) {
+	for _, v := range values {
+		if v == value {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,264 @@
+package excel
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/xuri/excelize/v2"
+
+	"go-burndown/burndown"
+	"go-burndown/config"
+	"go-burndown/jira"
+)
+
+const (
+	// The Work sheet's fixed columns, before the configured columns and the period pairs.
+	//revive:disable:var-naming
+	_WORK_STATUS_COL       = "D"
+	_WORK_SIZE_COL         = "F"
+	_WORK_FIRST_PERIOD_COL = 7
+)
+
+// workHeaders are the generated Work sheet headers: Issue Key, Summary, Type, Status, Assignee, Size, the configured
+// columns, then period pairs (oldest on right, newest on left).
+func workHeaders(config *config.Config, periods []burndown.Period) []string {
+	headers := []string{"Issue Key", "Summary", "Type", "Status", "Assignee", unitHeader(config, "Size")}
+	for _, column := range config.Columns {
+		headers = append(headers, column.HeaderOrDefault())
+	}
+	for periodIndex := len(periods) - 1; periodIndex >= 0; periodIndex-- {
+		key := periodKey(periods[periodIndex])
+		headers = append(headers, fmt.Sprintf("%% %s", key), fmt.Sprintf("EV %s", key))
+	}
+	return headers
+}
+
+// writeWorkSheet streams the Work sheet: one row per issue with its details and configured fields, then a % Complete and Earned Value pair
+// per period, newest first. Streaming keeps memory flat and generation fast for tens of thousands of issues.
+// Columns users added to a previous Work sheet follow, with rows kept for annotated issues no longer reported.
+// Unless the sheet comes from a template, it is laid out as a table with frozen headers, fitted columns and older
+// periods grouped. It returns the last row holding an issue, for formulas that sum over the sheet.
+func writeWorkSheet(f *excelize.File, config *config.Config, workSheet string, series burndown.Series, annotations workAnnotations, layout bool, hyperlinkStyleID, percentStyleID, dateStyleID, numStyleID int) (lastRow int, err error) {
+	periods := series.Timeline.Periods
+	firstPeriodCol := _WORK_FIRST_PERIOD_COL + len(config.Columns)
+
+	// Older periods are grouped, so they can be collapsed to leave the latest period in view, with the collapse button
+	// next to the latest period. The stream writer can't group columns, so outlineColumns does once the workbook is saved.
+	if layout {
+		if err := f.SetSheetProps(workSheet, &excelize.SheetPropsOptions{OutlineSummaryRight: boolPtr(false)}); err != nil {
+			return 0, errors.WithStack(err)
+		}
+	}
+
+	sw, err := f.NewStreamWriter(workSheet)
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+
+	headers := append(workHeaders(config, periods), annotations.headers...)
+
+	firstAnnotationCol := len(headers) - len(annotations.headers)
+	issuesByKey := make(map[string]*jira.Issue, len(series.Issues))
+	for _, progress := range series.Issues {
+		issuesByKey[progress.Issue.Key] = progress.Issue
+	}
+	if layout {
+		// Columns are sized to their contents up front, since a streamed sheet's columns are set before its rows.
+		widths := make([]int, len(headers))
+		for col, header := range headers {
+			widths[col] = fitWidth(0, header)
+		}
+		for i := range series.Issues {
+			issue := series.Issues[i].Issue
+			for col, text := range []string{issue.Key, issue.Fields.Summary, issue.GetType(), issue.GetStatus(), issue.Fields.Assignee.DisplayName} {
+				widths[col] = fitWidth(widths[col], text)
+			}
+			for i, column := range config.Columns {
+				col := _WORK_FIRST_PERIOD_COL - 1 + i
+				widths[col] = fitWidth(widths[col], cellText(columnValue(issue, column, config.ColumnFieldID(column), issuesByKey)))
+			}
+		}
+		for _, values := range annotations.values {
+			for i, value := range values {
+				widths[firstAnnotationCol+i] = fitWidth(widths[firstAnnotationCol+i], cellText(value))
+			}
+		}
+		// Each width goes ahead of those already set, and Excel expects the columns in order, so the last goes first.
+		for col := len(widths) - 1; col >= 0; col-- {
+			if err := sw.SetColWidth(col+1, col+1, colWidth(widths[col])); err != nil {
+				return 0, errors.WithStack(err)
+			}
+		}
+
+		// The header row and the issue key and summary stay in view.
+		panes, err := frozenPanes("C2")
+		if err != nil {
+			return 0, errors.WithStack(err)
+		}
+		if err := sw.SetPanes(panes); err != nil {
+			return 0, errors.WithStack(err)
+		}
+	}
+
+	headerRow := make([]interface{}, len(headers))
+	for i, header := range headers {
+		headerRow[i] = header
+	}
+	if err := sw.SetRow("A1", headerRow); err != nil {
+		return 0, errors.WithStack(err)
+	}
+
+	annotationRow := func(key string) []interface{} {
+		var row []interface{}
+		for _, value := range annotations.values[key] {
+			row = append(row, value)
+		}
+		return row
+	}
+	var keys []string
+
+	// Add issues data
+	for i := range series.Issues {
+		progress := &series.Issues[i]
+		issue := progress.Issue
+		rowNum := i + 2
+
+		row := []interface{}{
+			ticketLinkCell(config, issue.Key, hyperlinkStyleID),
+			issue.Fields.Summary,
+			issue.GetType(),
+			issue.GetStatus(),
+			issue.Fields.Assignee.DisplayName,
+			progress.Size,
+		}
+		for _, column := range config.Columns {
+			row = append(row, fieldCell(columnValue(issue, column, config.ColumnFieldID(column), issuesByKey), dateStyleID))
+		}
+
+		// Period data - newest period first to match header order
+		col := firstPeriodCol
+		for periodIndex := len(periods) - 1; periodIndex >= 0; periodIndex-- {
+			percentComplete := progress.PercentComplete[periodIndex]
+
+			// Percent complete as a fraction for Excel, blank if zero.
+			percent := excelize.Cell{StyleID: percentStyleID}
+			if percentComplete > 0 {
+				percent.Value = percentComplete // 0.0-1.0
+			}
+
+			// Earned Value formula: percent * the size the issue counted for in scope then, blank if percent is zero
+			// for easy display. The Size column is referenced while it is that size, so edits to it carry through.
+			percentCell, err := excelize.CoordinatesToCellName(col, rowNum)
+			if err != nil {
+				return 0, errors.WithStack(err)
+			}
+			var earnedValue interface{}
+			if percentComplete > 0 {
+				earnedValue = progress.EarnedValue(periodIndex)
+			}
+			scopeSize := fmt.Sprintf("$%s%d", _WORK_SIZE_COL, rowNum)
+			if !progress.InScope[periodIndex] || progress.ScopeSize[periodIndex] != progress.Size {
+				scopeSize = strconv.FormatFloat(progress.ScopeSize[periodIndex], 'f', -1, 64)
+			}
+			earnedFormula := fmt.Sprintf(`IF(%s=0, "", %s * %s)`, percentCell, percentCell, scopeSize)
+			earned := formulaCell(config, earnedFormula, earnedValue, numStyleID)
+
+			row = append(row, percent, earned)
+			col += 2
+		}
+
+		row = append(row, annotationRow(issue.Key)...)
+		if err := sw.SetRow(fmt.Sprintf("A%d", rowNum), row); err != nil {
+			return 0, errors.WithStack(err)
+		}
+		keys = append(keys, issue.Key)
+	}
+
+	// Annotated issues that are no longer reported keep their notes, with no progress.
+	reported := make(map[string]struct{}, len(keys))
+	for _, key := range keys {
+		reported[key] = struct{}{}
+	}
+	for _, key := range annotations.keys {
+		if _, ok := reported[key]; ok {
+			continue
+		}
+		row := []interface{}{key}
+		for range firstAnnotationCol - 1 {
+			row = append(row, nil)
+		}
+		row = append(row, annotationRow(key)...)
+		if err := sw.SetRow(fmt.Sprintf("A%d", len(keys)+2), row); err != nil {
+			return 0, errors.WithStack(err)
+		}
+		keys = append(keys, key)
+	}
+
+	// A table gives every column a filter. Table headers must be unique, which columns users added may not be.
+	if layout && !hasDuplicates(headers) {
+		table, err := sheetTable("WorkTable", len(headers), len(keys)+1)
+		if err != nil {
+			return 0, errors.WithStack(err)
+		}
+		if err := sw.AddTable(table); err != nil {
+			return 0, errors.WithStack(err)
+		}
+	}
+
+	// Comments and highlights are added before the sheet is flushed, which writes them with it.
+	if err := restoreWorkComments(f, workSheet, headers, keys, annotations); err != nil {
+		return 0, errors.WithStack(err)
+	}
+	if err := writeHighlights(f, config, workSheet, series, workHighlightRules); err != nil {
+		return 0, errors.WithStack(err)
+	}
+
+	if err := sw.Flush(); err != nil {
+		return 0, errors.WithStack(err)
+	}
+
+	return max(len(keys)+1, 2), nil
+}
+
+// olderPeriodCols are the Work sheet columns of every period but the latest, grouped so they can be collapsed.
+// There are none with a single period.
+func olderPeriodCols(config *config.Config, periods []burndown.Period) (firstCol, lastCol int) {
+	firstPeriodCol := _WORK_FIRST_PERIOD_COL + len(config.Columns)
+	return firstPeriodCol + 2, firstPeriodCol + 2*len(periods) - 1
+}
+
+// columnValue is an issue's value for a configured column of the field given. The epic of a subtask is its parent's,
+// so it is looked up among the reported issues.
+func columnValue(issue *jira.Issue, column config.ColumnConfig, field string, issuesByKey map[string]*jira.Issue) interface{} {
+	if column.Field == config.ColumnEpic {
+		if key := issue.EpicKey(issuesByKey); key != "" {
+			return key
+		}
+		return nil
+	}
+	return issue.FieldValue(field)
+}
+
+// fieldCell shows a configured field's value, formatting dates as dates.
+func fieldCell(value interface{}, dateStyleID int) interface{} {
+	if date, ok := value.(time.Time); ok {
+		return excelize.Cell{StyleID: dateStyleID, Value: date}
+	}
+	return value
+}
+
+// ticketLinkCell links an issue key to its ticket for a streamed sheet. Streamed sheets can't hold hyperlink relationships,
+// and adding relationships cell by cell slows down quadratically, so the link is a HYPERLINK formula showing the key.
+// When only values are written, the key is written alone, without a link or its style.
+func ticketLinkCell(config *config.Config, key string, hyperlinkStyleID int) excelize.Cell {
+	if !config.WritesFormulas() {
+		return excelize.Cell{Value: key}
+	}
+	return excelize.Cell{
+		StyleID: hyperlinkStyleID,
+		Formula: fmt.Sprintf(`HYPERLINK("%s", "%s")`, config.TicketUrl(key), key),
+		Value:   key,
+	}
+}
@@ -0,0 +1,143 @@
+// Package htmlreport provides functionality for exporting the burndown as a single self-contained HTML page.
+package htmlreport
+
+import (
+	_ "embed"
+	"html/template"
+	"math"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"go-burndown/burndown"
+	"go-burndown/chart"
+	"go-burndown/config"
+	"go-burndown/jira"
+)
+
+//go:embed report.html
+var reportTemplate string
+
+// page is the data the report template renders.
+type page struct {
+	GeneratedAt string
+	JQL         string
+	SizeUnit    string
+	PeriodUnit  string
+	Window      uint
+	// Latest is the burndown at the end of the latest period, if there are any periods.
+	Latest *burndown.Point
+	// Forecast is the projection from the latest period, if there is one yet.
+	Forecast *burndown.Forecast
+	Charts   []template.HTML
+	Issues   []issueRow
+}
+
+// issueRow is one issue's row of the issue table, with its progress at the end of the latest period.
+type issueRow struct {
+	Key             string
+	URL             string
+	Summary         string
+	Type            string
+	Status          string
+	Assignee        string
+	Size            float64
+	PercentComplete float64
+	EarnedValue     float64
+}
+
+// GenerateHTMLReport writes the burndown to the configured output file as one HTML page with inline SVG charts,
+// the forecast summary and a sortable issue table. The page has no external dependencies.
+func GenerateHTMLReport(config *config.Config, issues []jira.Issue, timeline burndown.Timeline) error {
+	series, err := burndown.NewSeries(config, issues, timeline)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	tmpl, err := template.New("report").Funcs(template.FuncMap{
+		"number":  formatNumber,
+		"percent": formatPercent,
+		"date":    formatDate,
+	}).Parse(reportTemplate)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	file, err := os.Create(config.OutputFile)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer func() { _ = file.Close() }()
+
+	if err := tmpl.Execute(file, newPage(config, series, time.Now())); err != nil {
+		return errors.WithStack(err)
+	}
+
+	return errors.WithStack(file.Close())
+}
+
+func newPage(config *config.Config, series burndown.Series, generatedAt time.Time) page {
+	p := page{
+		GeneratedAt: generatedAt.Format("2006-01-02 15:04"),
+		JQL:         config.JQL,
+		SizeUnit:    config.SizeUnit(),
+		PeriodUnit:  series.Timeline.Unit,
+		Window:      config.MovingAvgWeeks,
+	}
+
+	// The SVG is built by the chart package, which escapes all of its text.
+	style := chart.NewStyle(config)
+	for _, c := range []chart.Chart{
+		chart.Burndown(series, style), chart.Burnup(series, style), chart.Velocity(series, style), chart.Forecast(series, style),
+	} {
+		p.Charts = append(p.Charts, template.HTML(c.SVG()))
+	}
+
+	latestIndex := len(series.Points) - 1
+	if latestIndex >= 0 {
+		p.Latest = &series.Points[latestIndex]
+		if forecast, ok := series.Forecast(latestIndex); ok {
+			p.Forecast = &forecast
+		}
+	}
+
+	for i := range series.Issues {
+		progress := &series.Issues[i]
+		issue := progress.Issue
+		row := issueRow{
+			Key:      issue.Key,
+			URL:      config.TicketUrl(issue.Key),
+			Summary:  issue.Fields.Summary,
+			Type:     issue.GetType(),
+			Status:   issue.GetStatus(),
+			Assignee: issue.Fields.Assignee.DisplayName,
+			Size:     progress.Size,
+		}
+		if latestIndex >= 0 {
+			row.PercentComplete = progress.PercentComplete[latestIndex]
+			row.EarnedValue = progress.EarnedValue(latestIndex)
+		}
+		p.Issues = append(p.Issues, row)
+	}
+
+	return p
+}
+
+// formatNumber shows a size or velocity to at most two decimal places, as the Markdown report does.
+func formatNumber(value float64) string {
+	return strconv.FormatFloat(math.Round(value*100)/100, 'f', -1, 64)
+}
+
+func formatPercent(value float64) string {
+	return strconv.FormatFloat(value*100, 'f', 0, 64) + "%"
+}
+
+// formatDate formats a date, or a dash if there is none (such as a forecast at zero velocity).
+func formatDate(date time.Time) string {
+	if date.IsZero() {
+		return "—"
+	}
+	return date.Format("2006-01-02")
+}
@@ -0,0 +1,42 @@
+package jira
+
+import (
+	"strings"
+	"time"
+)
+
+// FieldValue retrieves a field by its ID for a configured column: text, a number, or a time for date fields. Lists are joined
+// with commas, and users, options, versions and sprints are shown by name. It is nil if the field is empty.
+func (issue *Issue) FieldValue(field string) interface{} {
+	switch value := issue.Fields.Raw[field].(type) {
+	case string:
+		// Due dates are plain dates, while created, updated and resolved dates are timestamps.
+		if date, err := time.Parse(time.DateOnly, value); err == nil {
+			return date
+		}
+		if date, err := time.Parse(_JIRA_RFC3339_TIME_LAYOUT, value); err == nil {
+			return date
+		}
+		if value == "" {
+			return nil
+		}
+		return value
+	case float64, bool:
+		return value
+	case map[string]interface{}:
+		if name := fieldValueName(value); name != "" {
+			return name
+		}
+	case []interface{}:
+		var names []string
+		for _, item := range value {
+			if name := fieldValueName(item); name != "" {
+				names = append(names, name)
+			}
+		}
+		if len(names) > 0 {
+			return strings.Join(names, ", ")
+		}
+	}
+	return nil
+}
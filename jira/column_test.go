@@ -0,0 +1,55 @@
+package jira
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFieldValue(t *testing.T) {
+	issue, err := ParseIssue([]byte(`{"key": "A-1", "fields": {
+		"labels": ["backend", "api"],
+		"components": [{"id": "1", "name": "Billing"}],
+		"priority": {"id": "2", "name": "High"},
+		"fixVersions": [],
+		"reporter": {"accountId": "5b10", "displayName": "Ada Lovelace"},
+		"parent": {"id": "10", "key": "A-0", "fields": {"summary": "Epic"}},
+		"duedate": "2025-03-31",
+		"created": "2025-01-06T09:30:00.000+0000",
+		"customfield_10020": [{"id": 1, "name": "Sprint 1", "state": "closed"}, {"id": 2, "name": "Sprint 2", "state": "active"}],
+		"customfield_10030": 3.5,
+		"customfield_10040": {"id": "7", "value": "Red"},
+		"customfield_10050": null}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		field string
+		value interface{}
+	}{
+		{field: "labels", value: "backend, api"},
+		{field: "components", value: "Billing"},
+		{field: "priority", value: "High"},
+		{field: "fixVersions", value: nil},
+		{field: "reporter", value: "Ada Lovelace"},
+		{field: "parent", value: "A-0"},
+		{field: "duedate", value: time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC)},
+		{field: "customfield_10020", value: "Sprint 1, Sprint 2"},
+		{field: "customfield_10030", value: 3.5},
+		{field: "customfield_10040", value: "Red"},
+		{field: "customfield_10050", value: nil},
+		{field: "customfield_99999", value: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			assert.Equal(t, tt.value, issue.FieldValue(tt.field))
+		})
+	}
+
+	created, ok := issue.FieldValue("created").(time.Time)
+	assert.True(t, ok)
+	assert.True(t, created.Equal(time.Date(2025, 1, 6, 9, 30, 0, 0, time.UTC)))
+}
@@ -14,14 +14,32 @@ type Fields struct {
 		Name string `json:"name"`
 	} `json:"status"`
 	Issuetype struct {
-		Name string `json:"name"`
+		Name    string `json:"name"`
+		Subtask bool   `json:"subtask"`
 	} `json:"issuetype"`
 	Assignee struct {
 		DisplayName string `json:"displayName"`
 	} `json:"assignee"`
+	Components  []Component `json:"components"`
+	Labels      []string    `json:"labels"`
+	FixVersions []Version   `json:"fixVersions"`
+	Parent      struct {
+		Key string `json:"key"`
+	} `json:"parent"`
 	Created      string                 `json:"created"`
 	Updated      string                 `json:"updated"`
 	CustomFields map[string]interface{} `json:"-"` // Will be populated from raw JSON.
+	Raw          map[string]interface{} `json:"-"` // Every field by ID, for configured columns.
+}
+
+// Component is a project component an issue belongs to.
+type Component struct {
+	Name string `json:"name"`
+}
+
+// Version is a project version, such as a release an issue is to be fixed in.
+type Version struct {
+	Name string `json:"name"`
 }
 
 // UnmarshalJSON custom unmarshals Fields to extract custom fields.
@@ -45,12 +63,45 @@ func (f *Fields) UnmarshalJSON(data []byte) error {
 		if name, ok := issuetype["name"].(string); ok {
 			f.Issuetype.Name = name
 		}
+		if subtask, ok := issuetype["subtask"].(bool); ok {
+			f.Issuetype.Subtask = subtask
+		}
 	}
 	if assignee, ok := raw["assignee"].(map[string]interface{}); ok {
 		if displayName, ok := assignee["displayName"].(string); ok {
 			f.Assignee.DisplayName = displayName
 		}
 	}
+	if components, ok := raw["components"].([]interface{}); ok {
+		for _, component := range components {
+			if componentObj, ok := component.(map[string]interface{}); ok {
+				if name, ok := componentObj["name"].(string); ok {
+					f.Components = append(f.Components, Component{Name: name})
+				}
+			}
+		}
+	}
+	if labels, ok := raw["labels"].([]interface{}); ok {
+		for _, label := range labels {
+			if name, ok := label.(string); ok {
+				f.Labels = append(f.Labels, name)
+			}
+		}
+	}
+	if fixVersions, ok := raw["fixVersions"].([]interface{}); ok {
+		for _, fixVersion := range fixVersions {
+			if versionObj, ok := fixVersion.(map[string]interface{}); ok {
+				if name, ok := versionObj["name"].(string); ok {
+					f.FixVersions = append(f.FixVersions, Version{Name: name})
+				}
+			}
+		}
+	}
+	if parent, ok := raw["parent"].(map[string]interface{}); ok {
+		if key, ok := parent["key"].(string); ok {
+			f.Parent.Key = key
+		}
+	}
 	if created, ok := raw["created"].(string); ok {
 		f.Created = created
 	}
@@ -58,6 +109,8 @@ func (f *Fields) UnmarshalJSON(data []byte) error {
 		f.Updated = updated
 	}
 
+	f.Raw = raw
+
 	// Extract custom fields
 	f.CustomFields = make(map[string]interface{})
 	for key, value := range raw {
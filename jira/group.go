@@ -0,0 +1,97 @@
+package jira
+
+import (
+	"strconv"
+	"time"
+
+	"go-burndown/config"
+)
+
+// AssigneeOnDate returns who the issue was assigned to at the end of a date, replaying assignee changes from the changelog.
+// It is empty if the issue was unassigned.
+func (issue *Issue) AssigneeOnDate(date time.Time) string {
+	return issue.itemValueBefore(date.AddDate(0, 0, 1), func(item HistoryItem) bool {
+		return item.Field == "assignee"
+	}, func(item HistoryItem, to bool) string {
+		if to {
+			return item.ToString
+		}
+		return item.FromString
+	}, issue.Fields.Assignee.DisplayName)
+}
+
+// GetTeam retrieves the team using the configured team field. Team fields may be plain text, a select list or an Atlassian team.
+func (issue *Issue) GetTeam(config *config.Config) string {
+	return fieldValueName(issue.Fields.CustomFields[config.Jira.TeamField])
+}
+
+// TeamOnDate returns the issue's team at the end of a date, replaying changes of the team field from the changelog.
+// It is empty if the issue had no team.
+func (issue *Issue) TeamOnDate(config *config.Config, date time.Time) string {
+	// Changes are matched on the field ID, since the changelog names the field by its display name.
+	return issue.itemValueBefore(date.AddDate(0, 0, 1), func(item HistoryItem) bool {
+		return item.FieldID == config.Jira.TeamField
+	}, func(item HistoryItem, to bool) string {
+		if to {
+			return item.ToString
+		}
+		return item.FromString
+	}, issue.GetTeam(config))
+}
+
+// GetComponents retrieves the names of the issue's components.
+func (issue *Issue) GetComponents() (names []string) {
+	for _, component := range issue.Fields.Components {
+		names = append(names, component.Name)
+	}
+	return names
+}
+
+// GetGroups retrieves the values of a group by field, such as the issue's labels. An issue may be in
+// several groups, or none. Custom fields may hold text, numbers, select list options or lists of them.
+func (issue *Issue) GetGroups(field string) (groups []string) {
+	switch field {
+	case config.GroupByLabels:
+		return issue.Fields.Labels
+	case config.GroupByComponents:
+		return issue.GetComponents()
+	case config.GroupByEpic:
+		if issue.Fields.Parent.Key != "" {
+			return []string{issue.Fields.Parent.Key}
+		}
+		return nil
+	case config.GroupByFixVersions:
+		for _, version := range issue.Fields.FixVersions {
+			groups = append(groups, version.Name)
+		}
+		return groups
+	}
+
+	values, ok := issue.Fields.CustomFields[field].([]interface{})
+	if !ok {
+		values = []interface{}{issue.Fields.CustomFields[field]}
+	}
+	for _, value := range values {
+		if name := fieldValueName(value); name != "" {
+			groups = append(groups, name)
+		}
+	}
+	return groups
+}
+
+// fieldValueName names a single field value: text, a number, or a user, option, version, sprint or team object.
+func fieldValueName(value interface{}) string {
+	switch value := value.(type) {
+	case string:
+		return value
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	case map[string]interface{}:
+		for _, key := range []string{"displayName", "value", "name", "title", "key"} {
+			if name, ok := value[key].(string); ok {
+				return name
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,109 @@
+package jira
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go-burndown/config"
+)
+
+func TestGroupsOnDate(t *testing.T) {
+	config := &config.Config{Jira: config.JiraConfig{TeamField: "customfield_10001"}}
+	date := func(value string) time.Time {
+		parsed, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return parsed
+	}
+
+	tests := []struct {
+		name     string
+		issue    string
+		date     time.Time
+		assignee string
+		team     string
+	}{
+		{
+			name:     "never changed",
+			issue:    `{"key": "A-1", "fields": {"assignee": {"displayName": "Ada"}, "customfield_10001": {"id": "7", "value": "Platform"}}}`,
+			date:     date("2025-01-09"),
+			assignee: "Ada",
+			team:     "Platform",
+		},
+		{
+			name: "before being reassigned",
+			issue: `{"key": "A-1", "fields": {"assignee": {"displayName": "Grace"}, "customfield_10001": "Mobile"},
+				"changelog": {"histories": [{"created": "2025-01-10T10:00:00.000+0000", "items": [
+					{"field": "assignee", "fromString": "Ada", "toString": "Grace"},
+					{"field": "Team", "fieldId": "customfield_10001", "fromString": "Platform", "toString": "Mobile"}]}]}}`,
+			date:     date("2025-01-09"),
+			assignee: "Ada",
+			team:     "Platform",
+		},
+		{
+			name: "after being reassigned",
+			issue: `{"key": "A-1", "fields": {"assignee": {"displayName": "Grace"}, "customfield_10001": {"id": "ari:team", "title": "Mobile"}},
+				"changelog": {"histories": [{"created": "2025-01-10T10:00:00.000+0000", "items": [
+					{"field": "assignee", "fromString": "Ada", "toString": "Grace"},
+					{"field": "Team", "fieldId": "customfield_10001", "fromString": "Platform", "toString": "Mobile"}]}]}}`,
+			date:     date("2025-01-10"),
+			assignee: "Grace",
+			team:     "Mobile",
+		},
+		{
+			name: "before being assigned",
+			issue: `{"key": "A-1", "fields": {"assignee": {"displayName": "Grace"}},
+				"changelog": {"histories": [{"created": "2025-01-10T10:00:00.000+0000", "items": [{"field": "assignee", "fromString": "", "toString": "Grace"}]}]}}`,
+			date:     date("2025-01-09"),
+			assignee: "",
+			team:     "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issue, err := ParseIssue([]byte(tt.issue))
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, tt.assignee, issue.AssigneeOnDate(tt.date))
+			assert.Equal(t, tt.team, issue.TeamOnDate(config, tt.date))
+		})
+	}
+}
+
+func TestGetGroups(t *testing.T) {
+	issue, err := ParseIssue([]byte(`{"key": "A-1", "fields": {
+		"labels": ["backend", "api"],
+		"components": [{"name": "Billing"}],
+		"fixVersions": [{"name": "2.0"}, {"name": "2.1"}],
+		"parent": {"key": "A-100"},
+		"customfield_10002": {"value": "Payments"},
+		"customfield_10003": [{"value": "EMEA"}, {"value": "APAC"}],
+		"customfield_10004": null}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		field  string
+		groups []string
+	}{
+		{field: "labels", groups: []string{"backend", "api"}},
+		{field: "components", groups: []string{"Billing"}},
+		{field: "epic", groups: []string{"A-100"}},
+		{field: "fixVersions", groups: []string{"2.0", "2.1"}},
+		{field: "customfield_10002", groups: []string{"Payments"}},
+		{field: "customfield_10003", groups: []string{"EMEA", "APAC"}},
+		{field: "customfield_10004", groups: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			assert.Equal(t, tt.groups, issue.GetGroups(tt.field))
+		})
+	}
+}
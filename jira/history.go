@@ -19,17 +19,23 @@ const (
 
 // History represents a changelog entry in Jira.
 type History struct {
-	Created string `json:"created"`
-	Items   []struct {
-		Field      string `json:"field"`
-		Fieldtype  string `json:"fieldtype"`
-		FromString string `json:"fromString"`
-		ToString   string `json:"toString"`
-	} `json:"items"`
+	Created string        `json:"created"`
+	Items   []HistoryItem `json:"items"`
 	// Internal private members.
 	createdTime time.Time
 }
 
+// HistoryItem represents a single field change within a changelog entry.
+type HistoryItem struct {
+	Field      string `json:"field"`
+	FieldID    string `json:"fieldId"`
+	Fieldtype  string `json:"fieldtype"`
+	From       string `json:"from"`
+	FromString string `json:"fromString"`
+	To         string `json:"to"`
+	ToString   string `json:"toString"`
+}
+
 func (issue *Issue) parseHistoryTimes() (err error) {
 	// Gater updated histories with a parsed times.
 	var updatedHistories []History
@@ -91,3 +97,111 @@ func (issue *Issue) PercentCompleteOnDate(config *config.Config, date time.Time)
 
 	return percentComplete, nil
 }
+
+// IsRegressedOnDate checks if the issue had gone backwards by the end of a date: moved from a done status back to an
+// open one, or had its percent complete lowered. Percent complete never decreases, so it overstates regressed
+// issues until they are done again or their percent complete is back to where it was.
+func (issue *Issue) IsRegressedOnDate(config *config.Config, date time.Time) (bool, error) {
+	beginningOfNextDay := date.AddDate(0, 0, 1)
+	wasDone := false
+	percentComplete, peakPercentComplete := 0.0, 0.0
+	for _, history := range issue.Changelog.Histories {
+		if !history.createdTime.Before(beginningOfNextDay) {
+			break
+		}
+		for _, item := range history.Items {
+			switch item.Field {
+			case config.Jira.PercentCompleteField:
+				val, err := strconv.ParseFloat(item.ToString, 64)
+				if err != nil {
+					return false, errors.WithStack(err)
+				}
+				percentComplete = val
+				peakPercentComplete = math.Max(peakPercentComplete, val)
+
+			case "status":
+				if config.IsDoneStatus(item.ToString) {
+					wasDone = true
+				}
+			}
+		}
+	}
+
+	status, err := issue.StatusOnDate(date)
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+	if status == "" || config.IsDoneStatus(status) || config.IsRemovedStatus(status) {
+		return false, nil
+	}
+	return wasDone || percentComplete < peakPercentComplete, nil
+}
+
+// StatusOnDate returns the issue's status at the end of a date, replaying status changes from the changelog.
+// The status is empty if the issue hadn't been created yet; issues without a creation date are taken to have always existed.
+func (issue *Issue) StatusOnDate(date time.Time) (status string, err error) {
+	beginningOfNextDay := date.AddDate(0, 0, 1)
+
+	created, err := issue.CreatedTime()
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	if !created.IsZero() && !created.Before(beginningOfNextDay) {
+		return "", nil
+	}
+
+	return issue.itemValueBefore(beginningOfNextDay, func(item HistoryItem) bool {
+		return item.Field == "status"
+	}, func(item HistoryItem, to bool) string {
+		if to {
+			return item.ToString
+		}
+		return item.FromString
+	}, issue.GetStatus()), nil
+}
+
+// StatusHistory returns the statuses the issue has been in, in order, starting with the status it was created in.
+func (issue *Issue) StatusHistory() (statuses []string) {
+	for _, history := range issue.Changelog.Histories {
+		for _, item := range history.Items {
+			if item.Field != "status" {
+				continue
+			}
+			if len(statuses) == 0 {
+				statuses = append(statuses, item.FromString)
+			}
+			statuses = append(statuses, item.ToString)
+		}
+	}
+	if len(statuses) == 0 {
+		statuses = append(statuses, issue.GetStatus())
+	}
+	return statuses
+}
+
+// itemValueBefore replays the changelog to find the value a field had just before the cutoff moment.
+// The value is taken from the last matching change before the cutoff; if the first matching change
+// is after the cutoff, the value it changed from is used; if the field never changed, current is used.
+// The value function picks which side of the change (raw or display string) is wanted.
+func (issue *Issue) itemValueBefore(cutoff time.Time, matches func(item HistoryItem) bool, value func(item HistoryItem, to bool) string, current string) string {
+	found := false
+	result := current
+	for _, history := range issue.Changelog.Histories {
+		for _, item := range history.Items {
+			if !matches(item) {
+				continue
+			}
+			if history.createdTime.Before(cutoff) {
+				result = value(item, true)
+				found = true
+				continue
+			}
+			if !found {
+				// The first change is after the cutoff, so it started from the value at the cutoff.
+				return value(item, false)
+			}
+			return result
+		}
+	}
+	return result
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"time"
 
 	"go-burndown/config"
 
@@ -19,6 +20,8 @@ type Issue struct {
 	Changelog struct {
 		Histories []History `json:"histories"`
 	} `json:"changelog"`
+	// Internal private members.
+	createdTime time.Time
 }
 
 func getIssueDetails(ctx context.Context, client *http.Client, auth, jiraURL, issueKey string) (*Issue, error) {
@@ -55,8 +58,13 @@ func getIssueDetails(ctx context.Context, client *http.Client, auth, jiraURL, is
 	}
 
 	// Parse response
+	return ParseIssue(body)
+}
+
+// ParseIssue parses an issue with its changelog, as returned by the Jira issue API.
+func ParseIssue(data []byte) (*Issue, error) {
 	var issue Issue
-	err = json.Unmarshal(body, &issue)
+	err := json.Unmarshal(data, &issue)
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
@@ -75,13 +83,67 @@ func (issue *Issue) GetStatus() string {
 	return issue.Fields.Status.Name
 }
 
+// CreatedTime returns when the issue was created, or the zero time if it has no creation date.
+// The parsed time is kept, since it is needed for every period.
+func (issue *Issue) CreatedTime() (created time.Time, err error) {
+	if issue.createdTime.IsZero() && issue.Fields.Created != "" {
+		issue.createdTime, err = time.Parse(_JIRA_RFC3339_TIME_LAYOUT, issue.Fields.Created)
+		if err != nil {
+			return time.Time{}, errors.WithStack(err)
+		}
+	}
+	return issue.createdTime, nil
+}
+
+// IsOpen checks if the issue is still to be done: neither done nor removed from scope.
+func (issue *Issue) IsOpen(config *config.Config) bool {
+	return !config.IsDoneStatus(issue.GetStatus()) && !config.IsRemovedStatus(issue.GetStatus())
+}
+
+// IsStaleOnDate checks if the issue is open and hadn't been updated for the configured number of days by the date.
+func (issue *Issue) IsStaleOnDate(config *config.Config, date time.Time) (bool, error) {
+	if !issue.IsOpen(config) || issue.Fields.Updated == "" {
+		return false, nil
+	}
+	updated, err := time.Parse(_JIRA_RFC3339_TIME_LAYOUT, issue.Fields.Updated)
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+	return updated.Before(date.AddDate(0, 0, -int(config.StaleDaysOrDefault()))), nil
+}
+
+// IsUnestimated checks if the issue is open with no size, so the remaining work is understated.
+// Issues are never unestimated when sizing by count.
+func (issue *Issue) IsUnestimated(config *config.Config) bool {
+	return !config.IsCountSizing() && issue.IsOpen(config) && issue.GetSize(config) == 0
+}
+
 // GetType retrieves the type of the ticket.
 func (issue *Issue) GetType() string {
 	return issue.Fields.Issuetype.Name
 }
 
+// EpicKey finds the key of the issue's epic: its parent, or for a subtask, its parent's parent, which is looked up among
+// the issues by key. It is empty if the issue has no parent, or is a subtask whose parent isn't among the issues.
+func (issue *Issue) EpicKey(issuesByKey map[string]*Issue) string {
+	if !issue.Fields.Issuetype.Subtask {
+		return issue.Fields.Parent.Key
+	}
+	if parent, ok := issuesByKey[issue.Fields.Parent.Key]; ok {
+		return parent.Fields.Parent.Key
+	}
+	return ""
+}
+
 // GetSize retrieves size using configurable field ID.
+// When sizing by count, each issue is one unit, weighted by its issue type if a weight is configured.
 func (issue *Issue) GetSize(config *config.Config) float64 {
+	if config.IsCountSizing() {
+		if weight, ok := config.Jira.TypeWeights[issue.GetType()]; ok {
+			return weight
+		}
+		return 1
+	}
 	if val, ok := issue.Fields.CustomFields[config.Jira.SizeField]; ok {
 		if f, ok := val.(float64); ok {
 			return f
@@ -0,0 +1,183 @@
+package jira
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go-burndown/config"
+)
+
+func TestIssueHealth(t *testing.T) {
+	pointsConfig := &config.Config{StaleDays: 10, Jira: config.JiraConfig{
+		SizeField:       "customfield_10016",
+		DoneStatuses:    []string{"Done"},
+		RemovedStatuses: []string{"Won't Do"},
+	}}
+	countConfig := &config.Config{Jira: config.JiraConfig{SizingMode: config.SizingCount, DoneStatuses: []string{"Done"}}}
+	date := time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		config      *config.Config
+		issue       string
+		unestimated bool
+		stale       bool
+	}{
+		{
+			name:   "estimated and recently updated",
+			config: pointsConfig,
+			issue:  `{"key": "A-1", "fields": {"status": {"name": "In Progress"}, "customfield_10016": 3, "updated": "2025-01-25T10:00:00.000+0000"}}`,
+		},
+		{
+			name:        "unestimated",
+			config:      pointsConfig,
+			issue:       `{"key": "A-1", "fields": {"status": {"name": "To Do"}, "updated": "2025-01-25T10:00:00.000+0000"}}`,
+			unestimated: true,
+		},
+		{
+			name:   "not updated within the stale days",
+			config: pointsConfig,
+			issue:  `{"key": "A-1", "fields": {"status": {"name": "In Progress"}, "customfield_10016": 3, "updated": "2025-01-20T10:00:00.000+0000"}}`,
+			stale:  true,
+		},
+		{
+			name:   "done issues are neither",
+			config: pointsConfig,
+			issue:  `{"key": "A-1", "fields": {"status": {"name": "Done"}, "updated": "2024-12-01T10:00:00.000+0000"}}`,
+		},
+		{
+			name:   "removed issues are neither",
+			config: pointsConfig,
+			issue:  `{"key": "A-1", "fields": {"status": {"name": "Won't Do"}, "updated": "2024-12-01T10:00:00.000+0000"}}`,
+		},
+		{
+			name:   "stale after the default 14 days, and never unestimated when sizing by count",
+			config: countConfig,
+			issue:  `{"key": "A-1", "fields": {"status": {"name": "To Do"}, "updated": "2025-01-16T10:00:00.000+0000"}}`,
+			stale:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issue, err := ParseIssue([]byte(tt.issue))
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, tt.unestimated, issue.IsUnestimated(tt.config))
+			stale, err := issue.IsStaleOnDate(tt.config, date)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.stale, stale)
+		})
+	}
+}
+
+func TestIsRegressedOnDate(t *testing.T) {
+	config := &config.Config{Jira: config.JiraConfig{
+		PercentCompleteField: "Percent",
+		DoneStatuses:         []string{"Done"},
+		RemovedStatuses:      []string{"Won't Do"},
+	}}
+	date := time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+	change := func(created, field, from, to string) string {
+		return `{"created": "` + created + `T10:00:00.000+0000", "items": [{"field": "` + field + `", "fromString": "` + from + `", "toString": "` + to + `"}]}`
+	}
+	issue := func(status string, histories ...string) string {
+		return `{"key": "A-1", "fields": {"status": {"name": "` + status + `"}}, "changelog": {"histories": [` + strings.Join(histories, ", ") + `]}}`
+	}
+
+	tests := []struct {
+		name      string
+		issue     string
+		regressed bool
+	}{
+		{
+			name:  "progressing",
+			issue: issue("In Progress", change("2025-01-10", "Percent", "", "0.3"), change("2025-01-20", "Percent", "0.3", "0.6")),
+		},
+		{
+			name:      "reopened",
+			issue:     issue("In Progress", change("2025-01-10", "status", "In Progress", "Done"), change("2025-01-20", "status", "Done", "In Progress")),
+			regressed: true,
+		},
+		{
+			name:  "reopened and done again",
+			issue: issue("Done", change("2025-01-10", "status", "In Progress", "Done"), change("2025-01-20", "status", "Done", "In Progress"), change("2025-01-25", "status", "In Progress", "Done")),
+		},
+		{
+			name:  "removed after being done",
+			issue: issue("Won't Do", change("2025-01-10", "status", "In Progress", "Done"), change("2025-01-20", "status", "Done", "Won't Do")),
+		},
+		{
+			name:      "percent complete lowered",
+			issue:     issue("In Progress", change("2025-01-10", "Percent", "", "0.6"), change("2025-01-20", "Percent", "0.6", "0.4")),
+			regressed: true,
+		},
+		{
+			name:  "percent complete back where it was",
+			issue: issue("In Progress", change("2025-01-10", "Percent", "", "0.6"), change("2025-01-20", "Percent", "0.6", "0.4"), change("2025-01-25", "Percent", "0.4", "0.7")),
+		},
+		{
+			name:  "lowered after the date",
+			issue: issue("In Progress", change("2025-01-10", "Percent", "", "0.6"), change("2025-02-05", "Percent", "0.6", "0.4")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issue, err := ParseIssue([]byte(tt.issue))
+			if err != nil {
+				t.Fatal(err)
+			}
+			regressed, err := issue.IsRegressedOnDate(config, date)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.regressed, regressed)
+		})
+	}
+}
+
+func TestEpicKey(t *testing.T) {
+	parse := func(issue string) *Issue {
+		parsed, err := ParseIssue([]byte(issue))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return parsed
+	}
+	story := parse(`{"key": "A-2", "fields": {"issuetype": {"name": "Story", "subtask": false}, "parent": {"key": "A-1"}}}`)
+	issuesByKey := map[string]*Issue{story.Key: story}
+
+	tests := []struct {
+		name  string
+		issue string
+		epic  string
+	}{
+		{
+			name:  "an issue's epic is its parent",
+			issue: `{"key": "A-2", "fields": {"issuetype": {"name": "Story", "subtask": false}, "parent": {"key": "A-1"}}}`,
+			epic:  "A-1",
+		},
+		{
+			name:  "no parent",
+			issue: `{"key": "A-3", "fields": {"issuetype": {"name": "Story", "subtask": false}}}`,
+		},
+		{
+			name:  "a subtask's epic is its parent's",
+			issue: `{"key": "A-4", "fields": {"issuetype": {"name": "Sub-task", "subtask": true}, "parent": {"key": "A-2"}}}`,
+			epic:  "A-1",
+		},
+		{
+			name:  "a subtask whose parent isn't among the issues",
+			issue: `{"key": "A-5", "fields": {"issuetype": {"name": "Sub-task", "subtask": true}, "parent": {"key": "B-2"}}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.epic, parse(tt.issue).EpicKey(issuesByKey))
+		})
+	}
+}
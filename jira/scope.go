@@ -0,0 +1,55 @@
+package jira
+
+import (
+	"strconv"
+	"time"
+
+	"go-burndown/config"
+
+	"github.com/pkg/errors"
+)
+
+// SizeOnDate returns the issue's size at the end of a date, replaying re-estimates of the size field from the changelog.
+// Unestimated issues are size zero. When sizing by count, the size never changes.
+func (issue *Issue) SizeOnDate(config *config.Config, date time.Time) (size float64, err error) {
+	if config.IsCountSizing() {
+		return issue.GetSize(config), nil
+	}
+
+	current := ""
+	if _, ok := issue.Fields.CustomFields[config.Jira.SizeField]; ok {
+		current = strconv.FormatFloat(issue.GetSize(config), 'f', -1, 64)
+	}
+
+	// Changes are matched on the field ID, since the changelog names the field by its display name.
+	value := issue.itemValueBefore(date.AddDate(0, 0, 1), func(item HistoryItem) bool {
+		return item.FieldID == config.Jira.SizeField
+	}, func(item HistoryItem, to bool) string {
+		if to {
+			return item.ToString
+		}
+		return item.FromString
+	}, current)
+
+	if value == "" {
+		return 0, nil
+	}
+	size, err = strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+	return size, nil
+}
+
+// InScopeOnDate checks whether the issue counted toward scope at the end of a date: it had been created,
+// and its status at the time wasn't one of the removed statuses.
+func (issue *Issue) InScopeOnDate(config *config.Config, date time.Time) (inScope bool, err error) {
+	status, err := issue.StatusOnDate(date)
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+	if status == "" {
+		return false, nil
+	}
+	return !config.IsRemovedStatus(status), nil
+}
@@ -0,0 +1,102 @@
+package jira
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go-burndown/config"
+)
+
+func TestScopeOnDate(t *testing.T) {
+	config := &config.Config{
+		Jira: config.JiraConfig{
+			SizeField:       "customfield_10016",
+			RemovedStatuses: []string{"Won't Do"},
+		},
+	}
+	date := func(value string) time.Time {
+		parsed, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return parsed
+	}
+
+	tests := []struct {
+		name    string
+		issue   string
+		date    time.Time
+		inScope bool
+		size    float64
+	}{
+		{
+			name:    "before being created",
+			issue:   `{"key": "A-1", "fields": {"created": "2025-01-10T10:00:00.000+0000", "status": {"name": "To Do"}, "customfield_10016": 3}}`,
+			date:    date("2025-01-09"),
+			inScope: false,
+			size:    3,
+		},
+		{
+			name:    "on the day it was created",
+			issue:   `{"key": "A-1", "fields": {"created": "2025-01-10T10:00:00.000+0000", "status": {"name": "To Do"}, "customfield_10016": 3}}`,
+			date:    date("2025-01-10"),
+			inScope: true,
+			size:    3,
+		},
+		{
+			name: "before being re-estimated",
+			issue: `{"key": "A-1", "fields": {"created": "2025-01-01T10:00:00.000+0000", "status": {"name": "To Do"}, "customfield_10016": 8},
+				"changelog": {"histories": [{"created": "2025-01-10T10:00:00.000+0000", "items": [{"field": "Story Points", "fieldId": "customfield_10016", "fromString": "5", "toString": "8"}]}]}}`,
+			date:    date("2025-01-09"),
+			inScope: true,
+			size:    5,
+		},
+		{
+			name: "before being estimated",
+			issue: `{"key": "A-1", "fields": {"created": "2025-01-01T10:00:00.000+0000", "status": {"name": "To Do"}, "customfield_10016": 8},
+				"changelog": {"histories": [{"created": "2025-01-10T10:00:00.000+0000", "items": [{"field": "Story Points", "fieldId": "customfield_10016", "fromString": "", "toString": "8"}]}]}}`,
+			date:    date("2025-01-09"),
+			inScope: true,
+			size:    0,
+		},
+		{
+			name: "after being removed",
+			issue: `{"key": "A-1", "fields": {"created": "2025-01-01T10:00:00.000+0000", "status": {"name": "Won't Do"}, "customfield_10016": 3},
+				"changelog": {"histories": [{"created": "2025-01-10T10:00:00.000+0000", "items": [{"field": "status", "fromString": "To Do", "toString": "Won't Do"}]}]}}`,
+			date:    date("2025-01-10"),
+			inScope: false,
+			size:    3,
+		},
+		{
+			name: "before being removed",
+			issue: `{"key": "A-1", "fields": {"created": "2025-01-01T10:00:00.000+0000", "status": {"name": "Won't Do"}, "customfield_10016": 3},
+				"changelog": {"histories": [{"created": "2025-01-10T10:00:00.000+0000", "items": [{"field": "status", "fromString": "To Do", "toString": "Won't Do"}]}]}}`,
+			date:    date("2025-01-09"),
+			inScope: true,
+			size:    3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var issue Issue
+			if err := json.Unmarshal([]byte(tt.issue), &issue); err != nil {
+				t.Fatal(err)
+			}
+			if err := issue.parseHistoryTimes(); err != nil {
+				t.Fatal(err)
+			}
+
+			inScope, err := issue.InScopeOnDate(config, tt.date)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.inScope, inScope)
+
+			size, err := issue.SizeOnDate(config, tt.date)
+			assert.NoError(t, err)
+			assert.InDelta(t, tt.size, size, 1e-9)
+		})
+	}
+}
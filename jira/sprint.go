@@ -0,0 +1,190 @@
+package jira
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-burndown/config"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// The changelog field name for sprint membership changes.
+	//revive:disable:var-naming
+	_SPRINT_FIELD = "Sprint"
+)
+
+// Sprint represents a sprint on a Jira Agile board.
+type Sprint struct {
+	ID           int    `json:"id"`
+	Name         string `json:"name"`
+	State        string `json:"state"`
+	StartDate    string `json:"startDate"`
+	EndDate      string `json:"endDate"`
+	CompleteDate string `json:"completeDate"`
+	// Internal private members.
+	startTime time.Time
+	endTime   time.Time
+}
+
+// SprintResponse represents a page of sprints from the Jira Agile API.
+type SprintResponse struct {
+	IsLast bool     `json:"isLast"`
+	Values []Sprint `json:"values"`
+}
+
+// QuerySprints fetches the started (active and closed) sprints of the configured board, ordered by end date.
+func QuerySprints(ctx context.Context, config *config.Config) ([]Sprint, error) {
+	// Create HTTP client
+	client := &http.Client{}
+
+	// Encode credentials
+	auth := base64.StdEncoding.EncodeToString([]byte(config.Jira.Username + ":" + config.Jira.APIToken))
+
+	// Fetch all sprints with pagination
+	var allSprints []Sprint
+	startAt := 0
+	maxResults := _RESULTS_PER_PAGE
+
+	for {
+		sprintURL := fmt.Sprintf("%s/rest/agile/1.0/board/%d/sprint?state=active,closed&startAt=%d&maxResults=%d", config.Jira.JiraURL, config.Jira.BoardID, startAt, maxResults)
+
+		req, err := http.NewRequestWithContext(ctx, "GET", sprintURL, http.NoBody)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		req.Header.Set("Authorization", "Basic "+auth)
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		body, err := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		if resp.StatusCode != 200 {
+			return nil, errors.Errorf("Jira sprint API returned status %d: %s", resp.StatusCode, string(body))
+		}
+
+		var sprintResp SprintResponse
+		err = json.Unmarshal(body, &sprintResp)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		for i := range sprintResp.Values {
+			sprint := sprintResp.Values[i]
+			if err := sprint.parseTimes(); err != nil {
+				return nil, errors.WithStack(err)
+			}
+			allSprints = append(allSprints, sprint)
+		}
+
+		// The agile API says when there are no more pages.
+		if sprintResp.IsLast || len(sprintResp.Values) == 0 {
+			break
+		}
+		// Still here? Move the starting point for the next query.
+		startAt += len(sprintResp.Values)
+
+		// Simple rate limiting: sleep 1 second between requests
+		time.Sleep(time.Second)
+	}
+
+	sort.SliceStable(allSprints, func(i, j int) bool {
+		return allSprints[i].endTime.Before(allSprints[j].endTime)
+	})
+
+	return allSprints, nil
+}
+
+func (sprint *Sprint) parseTimes() (err error) {
+	sprint.startTime, err = time.Parse(time.RFC3339, sprint.StartDate)
+	if err != nil {
+		return errors.Wrapf(err, "sprint %s has invalid start date", sprint.Name)
+	}
+
+	// A closed sprint ends when it was completed, which may differ from when it was planned to end.
+	endDate := sprint.EndDate
+	if sprint.CompleteDate != "" {
+		endDate = sprint.CompleteDate
+	}
+	sprint.endTime, err = time.Parse(time.RFC3339, endDate)
+	if err != nil {
+		return errors.Wrapf(err, "sprint %s has invalid end date", sprint.Name)
+	}
+
+	return nil
+}
+
+// Start returns when the sprint started.
+func (sprint *Sprint) Start() time.Time {
+	return sprint.startTime
+}
+
+// End returns when the sprint was completed, or is planned to end if it is still active.
+func (sprint *Sprint) End() time.Time {
+	return sprint.endTime
+}
+
+// InSprintAt checks if the issue belonged to the sprint at a given moment, replaying sprint changes from the changelog.
+// If the sprint never changed in the changelog, the configured sprint field (if any) gives the current membership.
+func (issue *Issue) InSprintAt(config *config.Config, sprintID int, moment time.Time) bool {
+	current := ""
+	if config.Jira.SprintField != "" {
+		current = strings.Join(sprintIDs(issue.Fields.CustomFields[config.Jira.SprintField]), ",")
+	}
+
+	sprints := issue.itemValueBefore(moment, func(item HistoryItem) bool {
+		return item.Field == _SPRINT_FIELD
+	}, func(item HistoryItem, to bool) string {
+		if to {
+			return item.To
+		}
+		return item.From
+	}, current)
+
+	return slices.Contains(splitIDs(sprints), strconv.Itoa(sprintID))
+}
+
+// sprintIDs extracts the sprint IDs from a sprint custom field value, a list of sprint objects.
+func sprintIDs(value interface{}) (ids []string) {
+	sprints, ok := value.([]interface{})
+	if !ok {
+		return nil
+	}
+	for _, sprint := range sprints {
+		if sprintObj, ok := sprint.(map[string]interface{}); ok {
+			if id, ok := sprintObj["id"].(float64); ok {
+				ids = append(ids, strconv.Itoa(int(id)))
+			}
+		}
+	}
+	return ids
+}
+
+// splitIDs splits a comma separated changelog value like "123, 124" into its IDs.
+func splitIDs(value string) (ids []string) {
+	for _, id := range strings.Split(value, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
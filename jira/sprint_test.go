@@ -0,0 +1,76 @@
+package jira
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go-burndown/config"
+)
+
+func TestInSprintAt(t *testing.T) {
+	moment := func(value string) time.Time {
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return parsed
+	}
+
+	tests := []struct {
+		name     string
+		issue    string
+		sprintID int
+		moment   time.Time
+		inSprint bool
+	}{
+		{
+			name:     "before being added",
+			issue:    `{"key": "A-1", "changelog": {"histories": [{"created": "2025-01-10T10:00:00.000+0000", "items": [{"field": "Sprint", "from": "", "to": "7"}]}]}}`,
+			sprintID: 7,
+			moment:   moment("2025-01-09T00:00:00Z"),
+			inSprint: false,
+		},
+		{
+			name:     "after being added",
+			issue:    `{"key": "A-1", "changelog": {"histories": [{"created": "2025-01-10T10:00:00.000+0000", "items": [{"field": "Sprint", "from": "", "to": "7"}]}]}}`,
+			sprintID: 7,
+			moment:   moment("2025-01-10T11:00:00Z"),
+			inSprint: true,
+		},
+		{
+			name:     "carried over to the next sprint",
+			issue:    `{"key": "A-1", "changelog": {"histories": [{"created": "2025-01-24T10:00:00.000+0000", "items": [{"field": "Sprint", "from": "7", "to": "7, 8"}]}]}}`,
+			sprintID: 8,
+			moment:   moment("2025-01-20T00:00:00Z"),
+			inSprint: false,
+		},
+		{
+			name:     "already in the sprint before its first change",
+			issue:    `{"key": "A-1", "changelog": {"histories": [{"created": "2025-01-24T10:00:00.000+0000", "items": [{"field": "Sprint", "from": "7", "to": "7, 8"}]}]}}`,
+			sprintID: 7,
+			moment:   moment("2025-01-20T00:00:00Z"),
+			inSprint: true,
+		},
+		{
+			name:     "current sprint field when never changed",
+			issue:    `{"key": "A-1", "fields": {"customfield_10020": [{"id": 9, "name": "Sprint 9"}]}, "changelog": {"histories": []}}`,
+			sprintID: 9,
+			moment:   moment("2025-01-20T00:00:00Z"),
+			inSprint: true,
+		},
+	}
+
+	config := &config.Config{Jira: config.JiraConfig{SprintField: "customfield_10020"}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var issue Issue
+			assert.NoError(t, json.Unmarshal([]byte(tt.issue), &issue))
+			assert.NoError(t, issue.parseHistoryTimes())
+			assert.Equal(t, tt.inSprint, issue.InSprintAt(config, tt.sprintID, tt.moment))
+		})
+	}
+}
@@ -0,0 +1,250 @@
+// Package jsonreport provides functionality for exporting the burndown as JSON with a stable, versioned schema.
+package jsonreport
+
+import (
+	"encoding/json"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"go-burndown/burndown"
+	"go-burndown/config"
+	"go-burndown/jira"
+)
+
+// SchemaVersion is the version of the report's schema (see schema.json). It is incremented whenever a field
+// is removed or changes meaning; adding fields does not change the version.
+const SchemaVersion = 2
+
+// Report is the root of the JSON report.
+type Report struct {
+	SchemaVersion int            `json:"schema_version"`
+	GeneratedAt   string         `json:"generated_at"`
+	Config        ConfigMetadata `json:"config"`
+	Periods       []Period       `json:"periods"`
+	Issues        []Issue        `json:"issues"`
+	Totals        []Totals       `json:"totals"`
+	Velocity      VelocityStats  `json:"velocity"`
+	Forecast      *Forecast      `json:"forecast"`
+}
+
+// ConfigMetadata describes how the report was generated. Credentials are never included.
+type ConfigMetadata struct {
+	JQL               string   `json:"jql"`
+	JiraURL           string   `json:"jira_url"`
+	StartDate         string   `json:"start_date"`
+	Period            string   `json:"period"`
+	PeriodUnit        string   `json:"period_unit"`
+	WorkdaysPerPeriod float64  `json:"workdays_per_period"`
+	MovingAvgWindow   uint     `json:"moving_avg_window"`
+	SizeUnit          string   `json:"size_unit"`
+	TargetDates       []string `json:"target_dates"`
+}
+
+// Period is one reporting period. Progress is measured as of the end of its end date.
+type Period struct {
+	Start  string  `json:"start"`
+	End    string  `json:"end"`
+	Sprint *string `json:"sprint"`
+}
+
+// Issue is one issue with its progress at the end of every period.
+type Issue struct {
+	Key      string          `json:"key"`
+	URL      string          `json:"url"`
+	Summary  string          `json:"summary"`
+	Type     string          `json:"type"`
+	Status   string          `json:"status"`
+	Assignee string          `json:"assignee"`
+	Size     float64         `json:"size"`
+	Progress []IssueProgress `json:"progress"`
+}
+
+// IssueProgress is an issue's progress at the end of one period.
+type IssueProgress struct {
+	PeriodEnd       string  `json:"period_end"`
+	PercentComplete float64 `json:"percent_complete"`
+	EarnedValue     float64 `json:"earned_value"`
+}
+
+// Totals is the burndown at the end of one period, mirroring a row of the Projections sheet.
+// Values that the Projections sheet leaves blank are null.
+type Totals struct {
+	PeriodEnd    string        `json:"period_end"`
+	Completed    float64       `json:"completed"`
+	Remaining    float64       `json:"remaining"`
+	Scope        float64       `json:"scope"`
+	Added        float64       `json:"added"`
+	Removed      float64       `json:"removed"`
+	Reestimated  float64       `json:"reestimated"`
+	ScopeChanges []ScopeChange `json:"scope_changes"`
+	Velocity     *float64      `json:"velocity"`
+	AvgVelocity  *float64      `json:"avg_velocity"`
+	StdDev       *float64      `json:"std_dev"`
+	Forecast     *Forecast     `json:"forecast"`
+}
+
+// ScopeChange is one issue's contribution to the change in scope since the previous period.
+type ScopeChange struct {
+	Key        string  `json:"key"`
+	Change     string  `json:"change"`
+	SizeBefore float64 `json:"size_before"`
+	SizeAfter  float64 `json:"size_after"`
+}
+
+// VelocityStats summarizes the velocity as of the latest period.
+type VelocityStats struct {
+	Window  uint     `json:"window"`
+	Latest  *float64 `json:"latest"`
+	Average *float64 `json:"average"`
+	StdDev  *float64 `json:"std_dev"`
+}
+
+// Forecast is the projected completion dates as of a period. A date is null if the velocity it's based on isn't positive.
+type Forecast struct {
+	AsOf string  `json:"as_of"`
+	Fast *string `json:"fast"`
+	Mean *string `json:"mean"`
+	Slow *string `json:"slow"`
+}
+
+// GenerateJSONReport writes the burndown as JSON to the configured output file.
+func GenerateJSONReport(config *config.Config, issues []jira.Issue, timeline burndown.Timeline) error {
+	series, err := burndown.NewSeries(config, issues, timeline)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	data, err := json.MarshalIndent(NewReport(config, series, time.Now()), "", "  ")
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if err := os.WriteFile(config.OutputFile, append(data, '\n'), 0o644); err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+// NewReport lays out the burndown series in the report schema.
+func NewReport(config *config.Config, series burndown.Series, generatedAt time.Time) Report {
+	report := Report{
+		SchemaVersion: SchemaVersion,
+		GeneratedAt:   generatedAt.UTC().Format(time.RFC3339),
+		Config: ConfigMetadata{
+			JQL:               config.JQL,
+			JiraURL:           config.Jira.JiraURL,
+			StartDate:         config.StartDate,
+			Period:            config.PeriodOrDefault(),
+			PeriodUnit:        series.Timeline.Unit,
+			WorkdaysPerPeriod: series.Timeline.WorkdaysPerPeriod,
+			MovingAvgWindow:   config.MovingAvgWeeks,
+			SizeUnit:          config.SizeUnit(),
+			TargetDates:       append([]string{}, config.TargetDates...),
+		},
+		Periods: []Period{},
+		Issues:  []Issue{},
+		Totals:  []Totals{},
+		Velocity: VelocityStats{
+			Window: config.MovingAvgWeeks,
+		},
+	}
+
+	for _, period := range series.Timeline.Periods {
+		reportPeriod := Period{Start: formatDate(period.Start), End: formatDate(period.End)}
+		if period.Sprint != nil {
+			reportPeriod.Sprint = &period.Sprint.Name
+		}
+		report.Periods = append(report.Periods, reportPeriod)
+	}
+
+	for i := range series.Issues {
+		progress := &series.Issues[i]
+		issue := progress.Issue
+		reportIssue := Issue{
+			Key:      issue.Key,
+			URL:      config.TicketUrl(issue.Key),
+			Summary:  issue.Fields.Summary,
+			Type:     issue.GetType(),
+			Status:   issue.GetStatus(),
+			Assignee: issue.Fields.Assignee.DisplayName,
+			Size:     progress.Size,
+			Progress: []IssueProgress{},
+		}
+		for periodIndex, period := range series.Timeline.Periods {
+			reportIssue.Progress = append(reportIssue.Progress, IssueProgress{
+				PeriodEnd:       formatDate(period.End),
+				PercentComplete: progress.PercentComplete[periodIndex],
+				EarnedValue:     progress.EarnedValue(periodIndex),
+			})
+		}
+		report.Issues = append(report.Issues, reportIssue)
+	}
+
+	for periodIndex, point := range series.Points {
+		totals := Totals{
+			PeriodEnd:    formatDate(point.Period.End),
+			Completed:    point.Completed,
+			Remaining:    point.Remaining,
+			Scope:        point.Scope,
+			Added:        point.Added,
+			Removed:      point.Removed,
+			Reestimated:  point.Reestimated,
+			ScopeChanges: []ScopeChange{},
+		}
+		for _, change := range point.ScopeChanges {
+			totals.ScopeChanges = append(totals.ScopeChanges, ScopeChange{
+				Key:        change.Issue.Key,
+				Change:     change.Kind,
+				SizeBefore: change.Before,
+				SizeAfter:  change.After,
+			})
+		}
+		if point.HasVelocity {
+			totals.Velocity = &point.Velocity
+		}
+		if point.HasAvg {
+			totals.AvgVelocity = &point.AvgVelocity
+		}
+		if forecast, ok := series.Forecast(periodIndex); ok {
+			totals.StdDev = &point.StdDev
+			totals.Forecast = newForecast(point, forecast)
+		}
+		report.Totals = append(report.Totals, totals)
+	}
+
+	// The headline statistics and forecast are as of the latest period.
+	if len(report.Totals) > 0 {
+		latest := report.Totals[len(report.Totals)-1]
+		report.Velocity.Latest = latest.Velocity
+		report.Velocity.Average = latest.AvgVelocity
+		report.Velocity.StdDev = latest.StdDev
+		report.Forecast = latest.Forecast
+	}
+
+	return report
+}
+
+func newForecast(point burndown.Point, forecast burndown.Forecast) *Forecast {
+	return &Forecast{
+		AsOf: formatDate(point.Period.End),
+		Fast: optionalDate(forecast.Fast),
+		Mean: optionalDate(forecast.Mean),
+		Slow: optionalDate(forecast.Slow),
+	}
+}
+
+func formatDate(date time.Time) string {
+	return date.Format("2006-01-02")
+}
+
+// optionalDate formats a date, or null if there is none.
+func optionalDate(date time.Time) *string {
+	if date.IsZero() {
+		return nil
+	}
+	formatted := formatDate(date)
+	return &formatted
+}
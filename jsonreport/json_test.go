@@ -0,0 +1,75 @@
+package jsonreport
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-burndown/burndown"
+	"go-burndown/config"
+	"go-burndown/jira"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+func TestNewReportGolden(t *testing.T) {
+	config := &config.Config{
+		OutputFile:     "burndown.json",
+		StartDate:      "2025-01-06",
+		JQL:            `project = "BURN"`,
+		MovingAvgWeeks: 4,
+		TargetDates:    []string{"2025-03-31"},
+		Jira: config.JiraConfig{
+			JiraURL:              "https://example.atlassian.net",
+			Username:             "user@example.com",
+			APIToken:             "secret",
+			SizeField:            "customfield_10016",
+			PercentCompleteField: "Percentage Complete",
+			DoneStatuses:         []string{"Done", "Closed"},
+		},
+	}
+
+	issues := loadIssues(t, filepath.Join("testdata", "issues.json"))
+
+	timeline, err := burndown.NewCalendarTimeline(config, time.Date(2025, 2, 24, 12, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	series, err := burndown.NewSeries(config, issues, timeline)
+	require.NoError(t, err)
+
+	report := NewReport(config, series, time.Date(2025, 2, 24, 12, 0, 0, 0, time.UTC))
+	actual, err := json.MarshalIndent(report, "", "  ")
+	require.NoError(t, err)
+	actual = append(actual, '\n')
+
+	goldenFile := filepath.Join("testdata", "report.golden.json")
+	if *update {
+		require.NoError(t, os.WriteFile(goldenFile, actual, 0o644))
+	}
+	expected, err := os.ReadFile(goldenFile)
+	require.NoError(t, err)
+
+	assert.Equal(t, string(expected), string(actual), "run `go test ./jsonreport -update` if the change is intended")
+	assert.NotContains(t, string(actual), "secret", "credentials must never be written")
+}
+
+func loadIssues(t *testing.T, filename string) (issues []jira.Issue) {
+	t.Helper()
+
+	data, err := os.ReadFile(filename)
+	require.NoError(t, err)
+
+	var rawIssues []json.RawMessage
+	require.NoError(t, json.Unmarshal(data, &rawIssues))
+	for _, rawIssue := range rawIssues {
+		issue, err := jira.ParseIssue(rawIssue)
+		require.NoError(t, err)
+		issues = append(issues, *issue)
+	}
+	return issues
+}
@@ -0,0 +1,233 @@
+// Package mdreport provides functionality for summarizing the burndown as Markdown, for status updates.
+package mdreport
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"go-burndown/burndown"
+	"go-burndown/config"
+	"go-burndown/jira"
+)
+
+const (
+	// The most movers to list.
+	//revive:disable:var-naming
+	_MAX_MOVERS = 5
+)
+
+// mover is an issue that progressed during the latest period.
+type mover struct {
+	progress *burndown.IssueProgress
+	before   float64 // Percent complete at the end of the previous period.
+	after    float64 // Percent complete at the end of the latest period.
+	earned   float64 // Earned value gained during the latest period.
+}
+
+// GenerateMarkdownReport writes a Markdown status summary of the latest period to the configured output file.
+func GenerateMarkdownReport(config *config.Config, issues []jira.Issue, timeline burndown.Timeline) error {
+	series, err := burndown.NewSeries(config, issues, timeline)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if err := os.WriteFile(config.OutputFile, []byte(Summary(config, series)), 0o644); err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+// Summary summarizes the latest period: the work completed and remaining, velocity, the forecast with its range,
+// the issues that moved the most and the issues that were completed.
+func Summary(config *config.Config, series burndown.Series) string {
+	var b strings.Builder
+	unit := config.SizeUnit()
+
+	latestIndex := len(series.Points) - 1
+	if latestIndex < 0 {
+		b.WriteString("# Burndown status\n\nThere are no reporting periods yet.\n")
+		return b.String()
+	}
+	latest := series.Points[latestIndex]
+	during := duringPeriod(config, latest.Period)
+
+	fmt.Fprintf(&b, "# Burndown status as of %s\n\n", formatDate(latest.Period.End))
+
+	percentDone := 0.0
+	if latest.Scope > 0 {
+		percentDone = latest.Completed / latest.Scope
+	}
+	fmt.Fprintf(&b, "- **Completed:** %s %s (%s of %s)\n", formatNumber(latest.Completed), unit, formatPercent(percentDone), formatNumber(latest.Scope))
+	fmt.Fprintf(&b, "- **Remaining:** %s %s\n", formatNumber(latest.Remaining), unit)
+	if latestIndex > 0 {
+		fmt.Fprintf(&b, "- **Scope change:** %s %s (%s added, %s removed, %s re-estimated) %s\n",
+			formatSigned(latest.Added-latest.Removed+latest.Reestimated), unit,
+			formatNumber(latest.Added), formatNumber(latest.Removed), formatSigned(latest.Reestimated), during)
+	}
+
+	velocity := "n/a"
+	if latest.HasVelocity {
+		velocity = formatNumber(latest.Velocity) + " " + unit
+	}
+	average := "n/a"
+	if latest.HasAvg {
+		average = formatNumber(latest.AvgVelocity) + " " + unit
+	}
+	fmt.Fprintf(&b, "- **Velocity:** %s %s, averaging %s per period over %d%s\n",
+		velocity, during, average, config.MovingAvgWeeks, series.Timeline.Unit)
+
+	if forecast, ok := series.Forecast(latestIndex); ok {
+		fmt.Fprintf(&b, "- **Forecast:** %s (range %s to %s, p68)\n",
+			formatForecastDate(forecast.Mean), formatForecastDate(forecast.Fast), formatForecastDate(forecast.Slow))
+	} else {
+		b.WriteString("- **Forecast:** not enough periods yet\n")
+	}
+
+	movers := biggestMovers(series, latestIndex)
+	fmt.Fprintf(&b, "\n## Biggest movers %s\n\n", during)
+	if len(movers) == 0 {
+		b.WriteString("No issues progressed.\n")
+	} else {
+		fmt.Fprintf(&b, "| Issue | Summary | Progress | Earned (%s) |\n", unit)
+		b.WriteString("|---|---|---|---:|\n")
+		for _, m := range movers {
+			fmt.Fprintf(&b, "| %s | %s | %s → %s | +%s |\n",
+				issueLink(config, m.progress.Issue), escapeTableCell(m.progress.Issue.Fields.Summary),
+				formatPercent(m.before), formatPercent(m.after), formatNumber(m.earned))
+		}
+	}
+
+	completed := newlyCompleted(series, latestIndex)
+	fmt.Fprintf(&b, "\n## Completed %s\n\n", during)
+	if len(completed) == 0 {
+		b.WriteString("No issues were completed.\n")
+	} else {
+		for _, progress := range completed {
+			fmt.Fprintf(&b, "- %s %s (%s %s)\n",
+				issueLink(config, progress.Issue), escapeText(progress.Issue.Fields.Summary), formatNumber(progress.Size), unit)
+		}
+	}
+
+	return b.String()
+}
+
+// biggestMovers are the issues that earned the most value during the period, most first.
+func biggestMovers(series burndown.Series, periodIndex int) (movers []mover) {
+	if periodIndex < 1 {
+		return nil
+	}
+	for i := range series.Issues {
+		progress := &series.Issues[i]
+		earned := progress.EarnedValue(periodIndex) - progress.EarnedValue(periodIndex-1)
+		if earned <= 0 {
+			continue
+		}
+		movers = append(movers, mover{
+			progress: progress,
+			before:   progress.PercentComplete[periodIndex-1],
+			after:    progress.PercentComplete[periodIndex],
+			earned:   earned,
+		})
+	}
+	sort.SliceStable(movers, func(a, b int) bool {
+		return movers[a].earned > movers[b].earned
+	})
+	if len(movers) > _MAX_MOVERS {
+		movers = movers[:_MAX_MOVERS]
+	}
+	return movers
+}
+
+// newlyCompleted are the issues that reached 100% during the period. In the first period, that's every complete issue.
+func newlyCompleted(series burndown.Series, periodIndex int) (completed []*burndown.IssueProgress) {
+	for i := range series.Issues {
+		progress := &series.Issues[i]
+		if progress.PercentComplete[periodIndex] < 1 {
+			continue
+		}
+		if periodIndex > 0 && progress.PercentComplete[periodIndex-1] >= 1 {
+			continue
+		}
+		completed = append(completed, progress)
+	}
+	return completed
+}
+
+// duringPeriod names the latest period for headings, e.g. "this week".
+func duringPeriod(config *config.Config, period burndown.Period) string {
+	if period.Sprint != nil {
+		return "in " + period.Sprint.Name
+	}
+	return duringCalendarPeriod(config.PeriodOrDefault())
+}
+
+// duringCalendarPeriod names the latest calendar period of the given length.
+func duringCalendarPeriod(period string) string {
+	switch period {
+	case config.PeriodDaily:
+		return "today"
+	case config.PeriodBiweekly:
+		return "these two weeks"
+	case config.PeriodMonthly:
+		return "this month"
+	default:
+		return "this week"
+	}
+}
+
+func issueLink(config *config.Config, issue *jira.Issue) string {
+	return "[" + escapeText(issue.Key) + "](" + config.TicketUrl(issue.Key) + ")"
+}
+
+// escapeText escapes characters that Markdown would treat as formatting.
+func escapeText(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		if strings.ContainsRune("\\`*_[]<>|#", r) {
+			b.WriteRune('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// escapeTableCell escapes text for a table cell, which must stay on one line.
+func escapeTableCell(text string) string {
+	return strings.Join(strings.Fields(escapeText(text)), " ")
+}
+
+func formatNumber(value float64) string {
+	return strconv.FormatFloat(math.Round(value*100)/100, 'f', -1, 64)
+}
+
+// formatSigned formats a change with its sign, e.g. "+3" or "-2".
+func formatSigned(value float64) string {
+	if value > 0 {
+		return "+" + formatNumber(value)
+	}
+	return formatNumber(value)
+}
+
+func formatPercent(value float64) string {
+	return strconv.FormatFloat(value*100, 'f', 0, 64) + "%"
+}
+
+func formatDate(date time.Time) string {
+	return date.Format("2006-01-02")
+}
+
+// formatForecastDate formats a projected date, which is missing if the velocity isn't positive.
+func formatForecastDate(date time.Time) string {
+	if date.IsZero() {
+		return "never at this velocity"
+	}
+	return formatDate(date)
+}
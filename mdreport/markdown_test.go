@@ -0,0 +1,67 @@
+package mdreport
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go-burndown/burndown"
+	"go-burndown/jira"
+)
+
+func TestBiggestMoversAndNewlyCompleted(t *testing.T) {
+	issue := func(key string, size float64, percentComplete ...float64) burndown.IssueProgress {
+		scopeSize := make([]float64, len(percentComplete))
+		for i := range scopeSize {
+			scopeSize[i] = size
+		}
+		return burndown.IssueProgress{Issue: &jira.Issue{Key: key}, Size: size, PercentComplete: percentComplete, ScopeSize: scopeSize}
+	}
+	series := burndown.Series{Issues: []burndown.IssueProgress{
+		issue("DONE-BEFORE", 5, 1, 1),
+		issue("FINISHED", 2, 0.5, 1),
+		issue("BIG-STEP", 8, 0, 0.5),
+		issue("NO-PROGRESS", 3, 0.2, 0.2),
+		issue("SMALL-STEP", 1, 0, 0.5),
+		issue("DONE-AT-ONCE", 1, 0, 1),
+	}}
+
+	tests := []struct {
+		name        string
+		periodIndex int
+		movers      []string
+		completed   []string
+	}{
+		{
+			name:        "first period has no movers and counts everything complete",
+			periodIndex: 0,
+			completed:   []string{"DONE-BEFORE"},
+		},
+		{
+			name:        "latest period",
+			periodIndex: 1,
+			movers:      []string{"BIG-STEP", "FINISHED", "DONE-AT-ONCE", "SMALL-STEP"},
+			completed:   []string{"FINISHED", "DONE-AT-ONCE"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var movers []string
+			for _, m := range biggestMovers(series, tt.periodIndex) {
+				movers = append(movers, m.progress.Issue.Key)
+			}
+			assert.Equal(t, tt.movers, movers)
+
+			var completed []string
+			for _, progress := range newlyCompleted(series, tt.periodIndex) {
+				completed = append(completed, progress.Issue.Key)
+			}
+			assert.Equal(t, tt.completed, completed)
+		})
+	}
+}
+
+func TestEscapeTableCell(t *testing.T) {
+	assert.Equal(t, `Fix \*bold\* \| pipes \[link\] on two lines`, escapeTableCell("Fix *bold* | pipes [link]\non  two lines"))
+}